@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package analyze
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var formats = []string{csolution.FormatText, csolution.FormatJSON, csolution.FormatSARIF}
+
+func analyzeSolution(cmd *cobra.Command, args []string) error {
+	var inputFile string
+	argCnt := len(args)
+	switch argCnt {
+	case 0:
+		return errutils.New(errutils.ErrRequireArg, "cbuild analyze --help")
+	case 1:
+		inputFile = args[0]
+	default:
+		_ = cmd.Help()
+		return errutils.New(errutils.ErrInvalidCmdLineArg)
+	}
+
+	err := utils.CheckCsolutionFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	tool, _ := cmd.Flags().GetString("tool")
+	if !slices.Contains(csolution.Analyzers, tool) {
+		return errutils.New(errutils.ErrUnknownAnalyzer, tool, strings.Join(csolution.Analyzers, ", "))
+	}
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(formats, format) {
+		return errutils.New(errutils.ErrInvalidFormat, format, strings.Join(formats, ", "))
+	}
+
+	contexts, _ := cmd.Flags().GetStringArray("context")
+	excludeContexts, _ := cmd.Flags().GetStringArray("exclude-context")
+	allowEmpty, _ := cmd.Flags().GetBool("allow-empty")
+	output, _ := cmd.Flags().GetString("output")
+	jobs, _ := cmd.Flags().GetInt("jobs")
+	includePacks, _ := cmd.Flags().GetBool("include-packs")
+
+	b := csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: utils.Runner{},
+			Options: builder.Options{
+				Contexts:        contexts,
+				ExcludeContexts: excludeContexts,
+				AllowEmpty:      allowEmpty,
+				Output:          output,
+				Jobs:            jobs,
+				Format:          format,
+				Analyzer:        tool,
+				IncludePacks:    includePacks,
+			},
+			InputFile: inputFile,
+		},
+	}
+	return b.Analyze()
+}
+
+var AnalyzeCmd = &cobra.Command{
+	Use:   "analyze <name>.csolution.yml [options]",
+	Short: "Run static analysis on the sources of the contexts",
+	Long: "Run static analysis on the sources of the contexts in a <name>.csolution.yml.\n" +
+		"The sources and their compiler options are read from the compile_commands.json files generated by 'cbuild setup'.\n" +
+		"Sources of packs are skipped unless '--include-packs' is given.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := analyzeSolution(cmd, args)
+		if err != nil {
+			log.Error(err)
+		}
+		return err
+	},
+}
+
+func init() {
+	AnalyzeCmd.DisableFlagsInUseLine = true
+	AnalyzeCmd.Flags().StringArrayP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>], '!<name>' excludes, 're:<regex>' matches a regular expression")
+	AnalyzeCmd.Flags().StringArrayP("exclude-context", "", []string{}, "Exclude context names [<project-name>][.<build-type>][+<target-type>] from the selection")
+	AnalyzeCmd.Flags().BoolP("allow-empty", "", false, "Do not fail when the context selection matches no context")
+	AnalyzeCmd.Flags().StringP("output", "O", "", "Base folder for output files, 'outdir' and 'tmpdir' (default \"Same as '*.csolution.yml'\")")
+	AnalyzeCmd.Flags().StringP("tool", "", csolution.AnalyzerClangTidy, "Static analysis tool [clang-tidy | cppcheck]")
+	AnalyzeCmd.Flags().StringP("format", "", csolution.FormatText, "Output format [text | json | sarif]")
+	AnalyzeCmd.Flags().IntP("jobs", "j", 8, "Number of files analyzed in parallel")
+	AnalyzeCmd.Flags().BoolP("include-packs", "", false, "Analyze the sources of packs and third-party components too")
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package analyze_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoot = "../../../../test"
+const testDir = "command"
+
+func init() {
+	inittest.TestInitialization(testRoot, testDir)
+}
+
+func TestAnalyzeCommand(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := testRoot + "/" + testDir + "/TestSolution/test.csolution.yml"
+
+	t.Run("no arguments", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"analyze"})
+		err := cmd.Execute()
+		assert.EqualError(err, "command requires an input file argument. Run 'cbuild analyze --help' for more information about a command")
+	})
+
+	t.Run("invalid file extension", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"analyze", "test.cprj"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("unknown tool", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"analyze", csolutionFile, "--tool", "lint"})
+		err := cmd.Execute()
+		assert.EqualError(err, "unknown analyzer 'lint'. Supported: clang-tidy, cppcheck")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"analyze", csolutionFile, "--tool", "cppcheck", "--format", "xml"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid output format 'xml'. Supported: text, json, sarif")
+	})
+}
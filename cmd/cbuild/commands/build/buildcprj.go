@@ -130,5 +130,5 @@ func init() {
 	BuildCPRJCmd.Flags().StringP("intdir", "i", "", "Set directory for intermediate files")
 	BuildCPRJCmd.Flags().StringP("outdir", "o", "", "Set directory for output binary files")
 	BuildCPRJCmd.Flags().StringP("update", "u", "", "Generate *.cprj file for reproducing current build")
-	BuildCPRJCmd.Flags().StringP("generator", "g", "Ninja", "Select build system generator")
+	BuildCPRJCmd.Flags().StringP("generator", "g", "Ninja", "Select build system generator [Ninja | Ninja Multi-Config | Unix Makefiles]")
 }
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package clean
+
+import (
+	"path/filepath"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func cleanSolution(cmd *cobra.Command, args []string) error {
+	var inputFile string
+	argCnt := len(args)
+	switch argCnt {
+	case 0:
+		err := errutils.New(errutils.ErrRequireArg, "cbuild clean --help")
+		log.Error(err)
+		return err
+	case 1:
+		inputFile = args[0]
+	default:
+		err := errutils.New(errutils.ErrInvalidCmdLineArg)
+		log.Error(err)
+		_ = cmd.Help()
+		return err
+	}
+
+	err := utils.CheckCsolutionFile(inputFile)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	contexts, _ := cmd.Flags().GetStringArray("context")
+	excludeContexts, _ := cmd.Flags().GetStringArray("exclude-context")
+	allowEmpty, _ := cmd.Flags().GetBool("allow-empty")
+	useContextSet, _ := cmd.Flags().GetBool("context-set")
+	output, _ := cmd.Flags().GetString("output")
+	toolchain, _ := cmd.Flags().GetString("toolchain")
+	useCbuildgen, _ := cmd.Flags().GetBool("cbuildgen")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	keep, _ := cmd.Flags().GetStringSlice("keep")
+	targetSet, _ := cmd.Flags().GetString("active")
+	useTargetSet := utils.IsOptionSet(cmd.Flags(), "active")
+
+	// -a option is not compatible with -c or -S
+	if useTargetSet && (len(contexts) > 0 || len(excludeContexts) > 0 || useContextSet) {
+		err := errutils.New(errutils.ErrInvalidTargetSetUsage)
+		log.Error(err)
+		return err
+	}
+
+	for _, pattern := range keep {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			err = errutils.New(errutils.ErrInvalidInputArg, "--keep "+pattern)
+			log.Error(err)
+			return err
+		}
+	}
+
+	configs, err := utils.GetInstallConfigs()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	b := csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: utils.Runner{
+				PlainOutput: true,
+			},
+			Options: builder.Options{
+				Contexts:        contexts,
+				ExcludeContexts: excludeContexts,
+				AllowEmpty:      allowEmpty,
+				UseContextSet:   useContextSet,
+				Output:          output,
+				Toolchain:       toolchain,
+				UseCbuild2CMake: !useCbuildgen,
+				TargetSet:       targetSet,
+				UseTargetSet:    useTargetSet,
+				Clean:           true,
+				DryRun:          dryRun,
+				Keep:            keep,
+			},
+			InputFile:      inputFile,
+			InstallConfigs: configs,
+		},
+	}
+
+	if err := b.Clean(); err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+var CleanCmd = &cobra.Command{
+	Use:   "clean <name>.csolution.yml [options]",
+	Short: "Remove intermediate and output files of <name>.csolution.yml",
+	Long: "Remove intermediate and output files of <name>.csolution.yml.\n" +
+		"Files matching '*.cbuild.yml' and '*.cbuild-run.yml' are always preserved.\n" +
+		"When contexts are selected, only their intermediate and output directories are cleaned\n" +
+		"and the CMake files shared by all contexts are kept.",
+	RunE: cleanSolution,
+}
+
+func init() {
+	CleanCmd.DisableFlagsInUseLine = true
+	CleanCmd.Flags().StringArrayP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>], '!<name>' excludes, 're:<regex>' matches a regular expression")
+	CleanCmd.Flags().StringArrayP("exclude-context", "", []string{}, "Exclude context names [<project-name>][.<build-type>][+<target-type>] from the selection")
+	CleanCmd.Flags().BoolP("allow-empty", "", false, "Do not fail when the context selection matches no context")
+	CleanCmd.Flags().BoolP("context-set", "S", false, "Select the context names from cbuild-set.yml")
+	CleanCmd.Flags().StringP("active", "a", "", "Select active target-set: <target-type>[@<set>]")
+	CleanCmd.Flags().StringP("output", "O", "", "Base folder for output files, 'outdir' and 'tmpdir' (default \"Same as '*.csolution.yml'\")")
+	CleanCmd.Flags().BoolP("cbuildgen", "", false, "Clean files generated by the legacy cbuildgen backend")
+	CleanCmd.Flags().BoolP("dry-run", "", false, "List the files to be removed and preserved without deleting them")
+	CleanCmd.Flags().StringSliceP("keep", "", []string{}, "Preserve additional files matching the glob pattern(s), e.g. '*.map'")
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package clean_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoot = "../../../../test"
+const testDir = "command"
+
+func init() {
+	inittest.TestInitialization(testRoot, testDir)
+}
+
+func TestCleanCommand(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+
+	t.Run("missing argument", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean"})
+		err := cmd.Execute()
+		assert.EqualError(err, "command requires an input file argument. Run 'cbuild clean --help' for more information about a command")
+	})
+
+	t.Run("multiple arguments", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean", csolutionFile, csolutionFile})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid command line argument")
+	})
+
+	t.Run("invalid file extension", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean", "test.cprj"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid file extension: 'test.cprj'. Expected: '.csolution.yml'")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean", "missing.csolution.yml"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean", csolutionFile, "--dry-run"})
+		err := cmd.Execute()
+		// installation configuration is not available in the test environment
+		assert.Error(err)
+		_, statErr := os.Stat(csolutionFile)
+		assert.Nil(statErr)
+	})
+
+	t.Run("invalid keep pattern", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean", csolutionFile, "--keep", "[", "--dry-run"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid input argument for '--keep ['")
+	})
+
+	t.Run("active with context", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean", csolutionFile, "-a", "CM0", "-c", "test.Debug+CM0"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid target-set usage. The '-a' option cannot be used with the '-c' or '-S'")
+	})
+
+	t.Run("test help", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"clean", "-h"})
+		err := cmd.Execute()
+		assert.Nil(err)
+	})
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// flags completed with values of the solution
+var flagCompletions = map[string]string{
+	"context":          csolution.CompleteContexts,
+	"exclude-context":  csolution.CompleteContexts,
+	"database-context": csolution.CompleteContexts,
+	"active":           csolution.CompleteTargetSets,
+	"toolchain":        csolution.CompleteToolchains,
+}
+
+// completeValues returns a completion function listing the values of the
+// solution given in the arguments
+func completeValues(kind string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var inputFile string
+		for _, arg := range args {
+			if strings.HasSuffix(arg, ".csolution.yml") || strings.HasSuffix(arg, ".csolution.yaml") {
+				inputFile = arg
+				break
+			}
+		}
+		if inputFile == "" && kind != csolution.CompleteToolchains {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		configs, err := utils.GetInstallConfigs()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		b := csolution.CSolutionBuilder{
+			BuilderParams: builder.BuilderParams{
+				Runner:         utils.Runner{PlainOutput: true},
+				InputFile:      inputFile,
+				InstallConfigs: configs,
+			},
+		}
+		values, err := b.GetCompletionValues(kind)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeInputFile completes the file arguments with the given extensions
+func completeInputFile(extensions ...string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return extensions, cobra.ShellCompDirectiveFilterFileExt
+	}
+}
+
+// registerCompletions adds the dynamic completion of the solution values and the
+// input file arguments to the command and its subcommands
+func registerCompletions(cmd *cobra.Command) {
+	for flagName, kind := range flagCompletions {
+		if cmd.Flags().Lookup(flagName) != nil || cmd.PersistentFlags().Lookup(flagName) != nil {
+			// the subcommands are shared by all root commands and may be registered already
+			_ = cmd.RegisterFlagCompletionFunc(flagName, completeValues(kind))
+		}
+	}
+	if cmd.ValidArgsFunction == nil && strings.Contains(cmd.Use, "<name>.csolution.yml") {
+		cmd.ValidArgsFunction = completeInputFile("csolution.yml", "csolution.yaml")
+	}
+	for _, subCmd := range cmd.Commands() {
+		registerCompletions(subCmd)
+	}
+}
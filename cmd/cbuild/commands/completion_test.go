@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletion(t *testing.T) {
+	assert := assert.New(t)
+
+	execute := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := commands.NewRootCmd()
+		cmd.SetOut(&buf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("completion scripts", func(t *testing.T) {
+		for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+			output, err := execute("completion", shell)
+			assert.Nil(err)
+			assert.Contains(output, "cbuild")
+		}
+	})
+
+	t.Run("solution file argument", func(t *testing.T) {
+		output, err := execute(cobra.ShellCompRequestCmd, "setup", "")
+		assert.Nil(err)
+		assert.Contains(output, "csolution.yml\ncsolution.yaml\n:8\n")
+	})
+
+	t.Run("root file argument", func(t *testing.T) {
+		output, err := execute(cobra.ShellCompRequestCmd, "")
+		assert.Nil(err)
+		assert.Contains(output, "csolution.yml\ncsolution.yaml\ncprj\n")
+	})
+
+	t.Run("contexts without solution", func(t *testing.T) {
+		output, err := execute(cobra.ShellCompRequestCmd, "setup", "-c", "")
+		assert.Nil(err)
+		assert.Contains(output, ":4\n")
+	})
+
+	t.Run("second file argument", func(t *testing.T) {
+		output, err := execute(cobra.ShellCompRequestCmd, "clean", "test.csolution.yml", "")
+		assert.Nil(err)
+		assert.Contains(output, ":4\n")
+	})
+}
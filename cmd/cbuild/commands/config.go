@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// options never taken from the environment or a configuration file
+var unconfigurableOptions = []string{"help", "version", "profile"}
+
+// configured options ignored when a conflicting option is given on the command line
+var conflictingOptions = map[string][]string{
+	"active":          {"context", "exclude-context", "context-set"},
+	"context":         {"active"},
+	"exclude-context": {"active"},
+	"context-set":     {"active"},
+}
+
+// getOptionNames returns the names of the options of all commands
+func getOptionNames(cmd *cobra.Command) map[string]bool {
+	names := make(map[string]bool)
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) { names[flag.Name] = true })
+		cmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) { names[flag.Name] = true })
+		for _, subCmd := range cmd.Commands() {
+			visit(subCmd)
+		}
+	}
+	visit(cmd.Root())
+	return names
+}
+
+// warnUnknownOptions warns about the keys of cbuild.config.yml which are no options
+// of any command, the keys are shared by all commands
+func warnUnknownOptions(cmd *cobra.Command, configValues map[string]string) {
+	optionNames := getOptionNames(cmd)
+	var unknown []string
+	for key := range configValues {
+		if !optionNames[key] || utils.Contains(unconfigurableOptions, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		log.Warn("ignoring unknown option '" + key + "' of " + utils.CbuildConfigFile)
+	}
+}
+
+// getSolutionDir returns the directory of the *.csolution.yml argument, if any
+func getSolutionDir(args []string) string {
+	for _, arg := range args {
+		if strings.HasSuffix(arg, ".csolution.yml") || strings.HasSuffix(arg, ".csolution.yaml") {
+			return filepath.Dir(arg)
+		}
+	}
+	return ""
+}
+
+// applyConfiguration sets the options not given on the command line. The
+// precedence is: command line > CBUILD_* environment variable > profile >
+// defaults of cbuild.config.yml. Configured values replace the defaults of the
+// options without marking them changed, see utils.IsOptionSet.
+func applyConfiguration(cmd *cobra.Command, args []string) error {
+	profile, _ := cmd.Flags().GetString("profile")
+	if !cmd.Flags().Changed("profile") {
+		profile = os.Getenv(utils.GetOptionEnvVar("profile"))
+	}
+
+	config, err := utils.LoadCbuildConfig(getSolutionDir(args))
+	if err != nil {
+		return err
+	}
+	configValues, err := config.GetValues(profile)
+	if err != nil {
+		return err
+	}
+	warnUnknownOptions(cmd, configValues)
+
+	var applyErr error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		// commands may be executed more than once, e.g. by the tests
+		delete(flag.Annotations, utils.ConfiguredAnnotation)
+		if applyErr != nil || flag.Changed || utils.Contains(unconfigurableOptions, flag.Name) {
+			return
+		}
+		for _, name := range conflictingOptions[flag.Name] {
+			if cmd.Flags().Changed(name) {
+				return
+			}
+		}
+		envVar := utils.GetOptionEnvVar(flag.Name)
+		value, origin := os.Getenv(envVar), envVar
+		if value == "" {
+			var ok bool
+			if value, ok = configValues[flag.Name]; !ok {
+				return
+			}
+			origin = utils.CbuildConfigFile
+		}
+		var err error
+		if array, ok := flag.Value.(pflag.SliceValue); ok && flag.Value.Type() == "stringArray" {
+			// the command line takes one item per option, configured lists are comma separated
+			err = array.Replace(strings.Split(value, ","))
+		} else {
+			err = flag.Value.Set(value)
+		}
+		if err != nil {
+			applyErr = errutils.New(errutils.ErrInvalidConfigValue, value, flag.Name, origin, err)
+			return
+		}
+		if flag.Annotations == nil {
+			flag.Annotations = make(map[string][]string)
+		}
+		flag.Annotations[utils.ConfiguredAnnotation] = []string{origin}
+		log.Debug("option '" + flag.Name + "=" + value + "' set from " + origin)
+	})
+	return applyErr
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfiguration(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", solutionDir)
+	t.Setenv("HOME", solutionDir)
+	t.Setenv("CBUILD_JOBS", "")
+	t.Setenv("CBUILD_PROFILE", "")
+	csolutionFile := filepath.Join(solutionDir, "test.csolution.yml")
+	_ = os.WriteFile(csolutionFile, []byte("solution:\n"), 0600)
+	config := "defaults:\n  jobs: 16\n" +
+		"profiles:\n  ci:\n    jobs: 0\n  release:\n    jobs: invalid\n"
+	_ = os.WriteFile(filepath.Join(solutionDir, "cbuild.config.yml"), []byte(config), 0600)
+
+	execute := func(args ...string) error {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+	const errInvalidJobs = "invalid number of job slots specified for parallel execution. Expected: j>0"
+
+	t.Run("profile overrides defaults", func(t *testing.T) {
+		err := execute(csolutionFile, "--profile", "ci")
+		assert.EqualError(err, errInvalidJobs)
+	})
+
+	t.Run("profile from environment", func(t *testing.T) {
+		t.Setenv("CBUILD_PROFILE", "ci")
+		err := execute(csolutionFile)
+		assert.EqualError(err, errInvalidJobs)
+	})
+
+	t.Run("environment overrides profile", func(t *testing.T) {
+		t.Setenv("CBUILD_JOBS", "4")
+		err := execute(csolutionFile, "--profile", "ci")
+		assert.Error(err)
+		assert.NotEqual(errInvalidJobs, err.Error())
+	})
+
+	t.Run("command line overrides environment", func(t *testing.T) {
+		t.Setenv("CBUILD_JOBS", "4")
+		err := execute(csolutionFile, "-j", "0")
+		assert.EqualError(err, errInvalidJobs)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		err := execute(csolutionFile, "--profile", "releas")
+		assert.EqualError(err, "unknown profile 'releas'. Did you mean 'release'?")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		err := execute(csolutionFile, "--profile", "release")
+		assert.ErrorContains(err, "invalid value 'invalid' of option 'jobs' from cbuild.config.yml")
+	})
+
+	t.Run("invalid environment value", func(t *testing.T) {
+		t.Setenv("CBUILD_JOBS", "many")
+		err := execute(csolutionFile)
+		assert.ErrorContains(err, "invalid value 'many' of option 'jobs' from CBUILD_JOBS")
+	})
+
+	t.Run("configured values are defaults", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{csolutionFile})
+		_ = cmd.Execute()
+		flag := cmd.Flags().Lookup("jobs")
+		assert.Equal("16", flag.Value.String())
+		assert.False(flag.Changed)
+		assert.True(utils.IsOptionSet(cmd.Flags(), "jobs"))
+		assert.False(utils.IsOptionSet(cmd.Flags(), "toolchain"))
+	})
+
+	t.Run("command line selection overrides configured target-set", func(t *testing.T) {
+		_ = os.WriteFile(filepath.Join(solutionDir, "cbuild.config.yml"), []byte("defaults:\n  active: CM0\n"), 0600)
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{csolutionFile, "-c", "test.Debug+CM0"})
+		err := cmd.Execute()
+		assert.NotEqual("invalid target-set usage. The '-a' option cannot be used with the '-c' or '-S'", err.Error())
+		assert.False(utils.IsOptionSet(cmd.Flags(), "active"))
+	})
+
+	t.Run("unknown options", func(t *testing.T) {
+		_ = os.WriteFile(filepath.Join(solutionDir, "cbuild.config.yml"), []byte("defaults:\n  jobs: 4\n  job: 4\n  junit: report.xml\n  version: true\n"), 0600)
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		_ = execute(csolutionFile)
+		assert.Contains(buf.String(), "ignoring unknown option 'job' of cbuild.config.yml")
+		assert.Contains(buf.String(), "ignoring unknown option 'version' of cbuild.config.yml")
+		// options of other commands are valid keys
+		assert.NotContains(buf.String(), "'junit'")
+	})
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package doctor
+
+import (
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func diagnose(cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		err := errutils.New(errutils.ErrAcceptNoArgs, "cbuild doctor --help")
+		log.Error(err)
+		return err
+	}
+
+	// an incomplete installation is reported by the checks
+	configs, err := utils.GetInstallConfigs()
+	if err != nil {
+		log.Warn(err.Error())
+	}
+
+	b := csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: utils.Runner{
+				PlainOutput: true,
+			},
+			InstallConfigs: configs,
+		},
+	}
+
+	if err := b.Doctor(); err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment cbuild depends on",
+	Long: "Check the tools, CMSIS_PACK_ROOT, CMSIS_COMPILER_ROOT, the registered toolchains and,\n" +
+		"when Zephyr is in use, the west setup. Each check reports pass, warn or fail with a hint how to fix it.",
+	RunE: diagnose,
+}
+
+func init() {
+	DoctorCmd.DisableFlagsInUseLine = true
+}
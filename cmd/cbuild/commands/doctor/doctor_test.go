@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package doctor_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoot = "../../../../test"
+const testDir = "command"
+
+func init() {
+	inittest.TestInitialization(testRoot, testDir)
+}
+
+func TestDoctorCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("invalid argument", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"doctor", "test.csolution.yml"})
+		err := cmd.Execute()
+		assert.EqualError(err, "command does not accept any arguments. Run 'cbuild doctor --help' for more information about a command")
+	})
+
+	t.Run("failing checks", func(t *testing.T) {
+		// the test executable is not part of a CMSIS-Toolbox installation
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"doctor"})
+		err := cmd.Execute()
+		assert.Error(err)
+		assert.Regexp(`^\d+ check\(s\) failed$`, err.Error())
+	})
+
+	t.Run("help", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"doctor", "-h"})
+		err := cmd.Execute()
+		assert.Nil(err)
+	})
+}
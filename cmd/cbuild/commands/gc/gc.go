@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package gc
+
+import (
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func collectGarbage(cmd *cobra.Command, args []string) error {
+	var inputFile string
+	argCnt := len(args)
+	switch argCnt {
+	case 0:
+		err := errutils.New(errutils.ErrRequireArg, "cbuild gc --help")
+		log.Error(err)
+		return err
+	case 1:
+		inputFile = args[0]
+	default:
+		err := errutils.New(errutils.ErrInvalidCmdLineArg)
+		log.Error(err)
+		_ = cmd.Help()
+		return err
+	}
+
+	err := utils.CheckCsolutionFile(inputFile)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	remove, _ := cmd.Flags().GetBool("yes")
+
+	configs, err := utils.GetInstallConfigs()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	b := csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: utils.Runner{
+				PlainOutput: true,
+			},
+			Options: builder.Options{
+				Output: output,
+			},
+			InputFile:      inputFile,
+			InstallConfigs: configs,
+		},
+	}
+
+	if err := b.CollectGarbage(remove); err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+var GcCmd = &cobra.Command{
+	Use:   "gc <name>.csolution.yml [options]",
+	Short: "Find and remove stale directories of contexts no longer in <name>.csolution.yml",
+	Long: "List context directories under 'tmpdir' and directories of the default 'out/<project>/<target-type>/<build-type>'\n" +
+		"layout that do not belong to any context of <name>.csolution.yml anymore. Other directories are never listed.\n" +
+		"The directories are only deleted with '--yes', which requires the <name>.cbuild-idx.yml generated by\n" +
+		"'cbuild setup' or a build.",
+	RunE: collectGarbage,
+}
+
+func init() {
+	GcCmd.DisableFlagsInUseLine = true
+	GcCmd.Flags().StringP("output", "O", "", "Base folder for output files, 'outdir' and 'tmpdir' (default \"Same as '*.csolution.yml'\")")
+	GcCmd.Flags().BoolP("yes", "y", false, "Delete the listed directories")
+}
@@ -1,17 +1,33 @@
 /*
- * Copyright (c) 2023-2025 Arm Limited. All rights reserved.
+ * Copyright (c) 2023-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
 package list
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
 	"github.com/spf13/cobra"
 )
 
+var formats = []string{csolution.FormatText, csolution.FormatJSON}
+
+// getFormat returns the validated value of the '--format' flag
+func getFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(formats, format) {
+		return "", errutils.New(errutils.ErrInvalidFormat, format, strings.Join(formats, ", "))
+	}
+	return format, nil
+}
+
 var ListCmd = &cobra.Command{
 	Use:   "list <command> [<name>.csolution.yml] [options]",
-	Short: "List information about contexts, environment, target-sets and toolchains",
+	Short: "List information about components, contexts, environment, outputs, target-sets and toolchains",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	},
@@ -19,6 +35,7 @@ var ListCmd = &cobra.Command{
 
 func init() {
 	ListCmd.DisableFlagsInUseLine = true
+	ListCmd.PersistentFlags().StringP("format", "", csolution.FormatText, "Output format [text | json]")
 	ListToolchainsCmd.SetHelpFunc(func(command *cobra.Command, strings []string) {
 		_ = command.Flags().MarkHidden("schema")
 		command.Parent().HelpFunc()(command, strings)
@@ -38,5 +55,15 @@ func init() {
 		_ = command.Flags().MarkHidden("toolchain")
 		command.Parent().HelpFunc()(command, strings)
 	})
-	ListCmd.AddCommand(ListContextsCmd, ListToolchainsCmd, ListEnvironmentCmd, ListTargetSetsCmd)
+	ListComponentsCmd.SetHelpFunc(func(command *cobra.Command, strings []string) {
+		_ = command.Flags().MarkHidden("schema")
+		_ = command.Flags().MarkHidden("toolchain")
+		command.Parent().HelpFunc()(command, strings)
+	})
+	ListOutputsCmd.SetHelpFunc(func(command *cobra.Command, strings []string) {
+		_ = command.Flags().MarkHidden("schema")
+		_ = command.Flags().MarkHidden("toolchain")
+		command.Parent().HelpFunc()(command, strings)
+	})
+	ListCmd.AddCommand(ListContextsCmd, ListToolchainsCmd, ListEnvironmentCmd, ListTargetSetsCmd, ListOutputsCmd, ListComponentsCmd)
 }
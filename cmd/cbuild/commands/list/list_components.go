@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package list
+
+import (
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func listComponents(cmd *cobra.Command, args []string) error {
+	var inputFile string
+	argCnt := len(args)
+	switch argCnt {
+	case 0:
+		return errutils.New(errutils.ErrRequireArg, "cbuild list components --help")
+	case 1:
+		inputFile = args[0]
+	default:
+		err := errutils.New(errutils.ErrInvalidCmdLineArg)
+		log.Error(err)
+		_ = cmd.Help()
+		return err
+	}
+
+	err := utils.CheckCsolutionFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	contexts, _ := cmd.Flags().GetStringArray("context")
+	excludeContexts, _ := cmd.Flags().GetStringArray("exclude-context")
+	allowEmpty, _ := cmd.Flags().GetBool("allow-empty")
+	output, _ := cmd.Flags().GetString("output")
+	diff, _ := cmd.Flags().GetBool("diff")
+
+	p := csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Options: builder.Options{
+				Contexts:        contexts,
+				ExcludeContexts: excludeContexts,
+				AllowEmpty:      allowEmpty,
+				Output:          output,
+				Format:          format,
+				Diff:            diff,
+			},
+			InputFile: inputFile,
+		},
+	}
+	return p.ListComponents()
+}
+
+var ListComponentsCmd = &cobra.Command{
+	Use:   "components <name>.csolution.yml [options]",
+	Short: "Print list of components used by the contexts in a <name>.csolution.yml",
+	Long: "Print list of components used by the contexts in a <name>.csolution.yml.\n" +
+		"The components are read from the cbuild.yml files of an earlier setup or build.\n" +
+		"With '--diff' the components of exactly two selected contexts are compared.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := listComponents(cmd, args)
+		if err != nil {
+			log.Error(err)
+		}
+		return err
+	},
+}
+
+func init() {
+	ListComponentsCmd.DisableFlagsInUseLine = true
+	ListComponentsCmd.Flags().StringArrayP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>], '!<name>' excludes, 're:<regex>' matches a regular expression")
+	ListComponentsCmd.Flags().StringArrayP("exclude-context", "", []string{}, "Exclude context names [<project-name>][.<build-type>][+<target-type>] from the selection")
+	ListComponentsCmd.Flags().BoolP("allow-empty", "", false, "Do not fail when the context selection matches no context")
+	ListComponentsCmd.Flags().StringP("output", "O", "", "Base folder for output files, 'outdir' and 'tmpdir' (default \"Same as '*.csolution.yml'\")")
+	ListComponentsCmd.Flags().BoolP("diff", "", false, "Show the differences between the components of two contexts")
+}
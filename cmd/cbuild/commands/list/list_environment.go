@@ -19,6 +19,11 @@ func listEnvironment(cmd *cobra.Command, args []string) error {
 		return errutils.New(errutils.ErrAcceptNoArgs, "cbuild list environment --help")
 	}
 
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
 	configs, err := utils.GetInstallConfigs()
 	if err != nil {
 		return err
@@ -29,6 +34,9 @@ func listEnvironment(cmd *cobra.Command, args []string) error {
 			Runner: utils.Runner{
 				PlainOutput: true,
 			},
+			Options: builder.Options{
+				Format: format,
+			},
 			InstallConfigs: configs,
 		},
 	}
@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2023 Arm Limited. All rights reserved.
+ * Copyright (c) 2023-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -15,19 +15,43 @@ import (
 
 func TestListEnvironmentCommand(t *testing.T) {
 	assert := assert.New(t)
+	const errInstallation = "couldn't locate '../etc' directory"
 
 	t.Run("invalid args", func(t *testing.T) {
 		cmd := commands.NewRootCmd()
 		cmd.SetArgs([]string{"list", "environment", "--invalid"})
 		err := cmd.Execute()
-		assert.Error(err)
+		assert.EqualError(err, "unknown flag: --invalid")
 	})
 
 	t.Run("test list environment", func(t *testing.T) {
 		cmd := commands.NewRootCmd()
 		cmd.SetArgs([]string{"list", "environment"})
 		err := cmd.Execute()
-		assert.Error(err)
+		// the test executable is not located in a CMSIS-Toolbox installation
+		assert.ErrorContains(err, errInstallation)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "environment", "--format", "yaml"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid output format 'yaml'. Supported: text, json")
+	})
+
+	t.Run("test list environment json format", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "environment", "--format", "json"})
+		err := cmd.Execute()
+		// the format is accepted, the JSON output is checked by the csolution builder tests
+		assert.ErrorContains(err, errInstallation)
+	})
+
+	t.Run("test list environment text format", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "environment", "--format", "text"})
+		err := cmd.Execute()
+		assert.ErrorContains(err, errInstallation)
 	})
 
 	t.Run("test help", func(t *testing.T) {
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package list_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOutputsCommand(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+
+	t.Run("No arguments", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "outputs"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("multiple arguments", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "outputs", csolutionFile, csolutionFile})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("invalid file extension", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "outputs", "test.cproject.yml"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "outputs", csolutionFile, "-c", "test.Debug+CM0"})
+		err := cmd.Execute()
+		assert.Error(err)
+		assert.Contains(err.Error(), "Did you mean 'test2.Debug+CM0'?")
+	})
+
+	t.Run("missing cbuild file", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "outputs", csolutionFile, "-c", "test2.Debug+CM0", "--format", "json"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("test list outputs help", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"list", "outputs", "-h"})
+		err := cmd.Execute()
+		assert.Nil(err)
+	})
+}
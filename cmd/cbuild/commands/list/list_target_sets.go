@@ -6,9 +6,6 @@
 package list
 
 import (
-	"path/filepath"
-	"strings"
-
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
@@ -32,13 +29,12 @@ func listTargetSets(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fileName := filepath.Base(inputFile)
-	expectedExtension := ".csolution.yml"
-	if !strings.HasSuffix(fileName, expectedExtension) && !strings.HasSuffix(fileName, ".csolution.yaml") {
-		return errutils.New(errutils.ErrInvalidFileExtension, fileName, expectedExtension)
+	err := utils.CheckCsolutionFile(inputFile)
+	if err != nil {
+		return err
 	}
 
-	_, err := utils.FileExists(inputFile)
+	format, err := getFormat(cmd)
 	if err != nil {
 		return err
 	}
@@ -62,6 +58,7 @@ func listTargetSets(cmd *cobra.Command, args []string) error {
 				SchemaChk: !noSchemaChk,
 				Filter:    filter,
 				Quiet:     quiet,
+				Format:    format,
 				Verbose:   verbose,
 			},
 			InputFile:      args[0],
@@ -6,9 +6,6 @@
 package list
 
 import (
-	"path/filepath"
-	"strings"
-
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
@@ -23,14 +20,7 @@ func listToolchains(cmd *cobra.Command, args []string) error {
 	if argCnt == 1 {
 		inputFile = args[0]
 
-		fileName := filepath.Base(inputFile)
-		expectedExtension := ".csolution.yml"
-		if !strings.HasSuffix(fileName, expectedExtension) && !strings.HasSuffix(fileName, ".csolution.yaml") {
-			return errutils.New(errutils.ErrInvalidFileExtension, fileName, expectedExtension)
-		}
-
-		_, err := utils.FileExists(inputFile)
-		if err != nil {
+		if err := utils.CheckCsolutionFile(inputFile); err != nil {
 			return err
 		}
 	} else if argCnt > 1 {
@@ -40,16 +30,21 @@ func listToolchains(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
 	configs, err := utils.GetInstallConfigs()
 	if err != nil {
 		return err
 	}
 
-	contexts, _ := cmd.Flags().GetStringSlice("context")
+	contexts, _ := cmd.Flags().GetStringArray("context")
 	useContextSet, _ := cmd.Flags().GetBool("context-set")
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	targetSet, _ := cmd.Flags().GetString("active")
-	useTargetSet := cmd.Flags().Changed("active")
+	useTargetSet := utils.IsOptionSet(cmd.Flags(), "active")
 
 	p := csolution.CSolutionBuilder{
 		BuilderParams: builder.BuilderParams{
@@ -62,6 +57,7 @@ func listToolchains(cmd *cobra.Command, args []string) error {
 				Verbose:       verbose,
 				TargetSet:     targetSet,
 				UseTargetSet:  useTargetSet,
+				Format:        format,
 			},
 			InputFile:      inputFile,
 			InstallConfigs: configs,
@@ -73,6 +69,10 @@ func listToolchains(cmd *cobra.Command, args []string) error {
 var ListToolchainsCmd = &cobra.Command{
 	Use:   "toolchains [<name>.csolution.yml] [options]",
 	Short: "Print list of registered toolchains or toolchains supported by <name>.csolution.yml",
+	Long: "Print list of registered toolchains or toolchains supported by <name>.csolution.yml.\n" +
+		"Toolchains are registered with <name>_TOOLCHAIN_<major>_<minor>_<patch> environment variables or in\n" +
+		"'toolchains.yml' files located in the user configuration directory ('cbuild/toolchains.yml') and\n" +
+		"next to <name>.csolution.yml. Environment variables take precedence over the files.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		err := listToolchains(cmd, args)
 		if err != nil {
@@ -85,7 +85,7 @@ var ListToolchainsCmd = &cobra.Command{
 func init() {
 	ListToolchainsCmd.DisableFlagsInUseLine = true
 	ListToolchainsCmd.Flags().BoolP("context-set", "S", false, "Select the context names from cbuild-set.yml for generating the target application")
-	ListToolchainsCmd.Flags().StringSliceP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>]")
+	ListToolchainsCmd.Flags().StringArrayP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>]")
 	ListToolchainsCmd.Flags().BoolP("verbose", "v", false, "Enable verbose messages")
 	ListToolchainsCmd.Flags().StringP("active", "a", "", "Select active target-set: <target-type>[@<set>]")
 }
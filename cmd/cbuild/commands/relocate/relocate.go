@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package relocate
+
+import (
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func relocate(cmd *cobra.Command, args []string) error {
+	var inputFile string
+	argCnt := len(args)
+	switch argCnt {
+	case 0:
+		err := errutils.New(errutils.ErrRequireArg, "cbuild relocate --help")
+		log.Error(err)
+		return err
+	case 1:
+		inputFile = args[0]
+	default:
+		err := errutils.New(errutils.ErrInvalidCmdLineArg)
+		log.Error(err)
+		_ = cmd.Help()
+		return err
+	}
+
+	err := utils.CheckCsolutionFile(inputFile)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	b := csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: utils.Runner{
+				PlainOutput: true,
+			},
+			Options: builder.Options{
+				Output: output,
+			},
+			InputFile: inputFile,
+		},
+	}
+
+	if err := b.Relocate(dryRun); err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+var RelocateCmd = &cobra.Command{
+	Use:   "relocate <name>.csolution.yml [options]",
+	Short: "Update the build tree of <name>.csolution.yml after moving the solution",
+	Long: "Replace the former solution location in the CMake files of 'tmpdir' after the solution was moved\n" +
+		"or renamed together with its output directories, so that the next build is incremental instead of a full rebuild.\n" +
+		"The former location is the solution directory recorded by the last build in 'tmpdir'.",
+	RunE: relocate,
+}
+
+func init() {
+	RelocateCmd.DisableFlagsInUseLine = true
+	RelocateCmd.Flags().StringP("output", "O", "", "Base folder for output files, 'outdir' and 'tmpdir' (default \"Same as '*.csolution.yml'\")")
+	RelocateCmd.Flags().BoolP("dry-run", "", false, "List the files to be updated without modifying them")
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package relocate_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoot = "../../../../test"
+const testDir = "command"
+
+func init() {
+	inittest.TestInitialization(testRoot, testDir)
+}
+
+func TestRelocateCommand(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+
+	t.Run("missing argument", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"relocate"})
+		err := cmd.Execute()
+		assert.EqualError(err, "command requires an input file argument. Run 'cbuild relocate --help' for more information about a command")
+	})
+
+	t.Run("multiple arguments", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"relocate", csolutionFile, csolutionFile})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid command line argument")
+	})
+
+	t.Run("invalid file extension", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"relocate", "test.cprj"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid file extension: 'test.cprj'. Expected: '.csolution.yml'")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"relocate", "missing.csolution.yml"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("missing build tree", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"relocate", csolutionFile, "--dry-run"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("test help", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"relocate", "-h"})
+		err := cmd.Execute()
+		assert.Nil(err)
+	})
+}
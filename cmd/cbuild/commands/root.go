@@ -13,9 +13,16 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/analyze"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/build"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/clean"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/doctor"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/gc"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/list"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/relocate"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/serve"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/setup"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/test"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands/zephyr"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/cproject"
@@ -62,6 +69,14 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 `
 
 func preConfiguration(cmd *cobra.Command, args []string) error {
+	// options from the environment and cbuild.config.yml
+	if versionFlag, _ := cmd.Flags().GetBool("version"); !versionFlag {
+		if err := applyConfiguration(cmd, args); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
 	// configure log level
 	log.SetLevel(logrus.WarnLevel)
 	debug, _ := cmd.Flags().GetBool("debug")
@@ -85,7 +100,8 @@ func preConfiguration(cmd *cobra.Command, args []string) error {
 				return err
 			}
 		}
-		file, err := os.Create(logFile)
+		// append mode allows the build tools output to be added to the same file
+		file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0666)
 		if err != nil {
 			log.Error(err)
 			return err
@@ -126,7 +142,9 @@ func NewRootCmd() *cobra.Command {
 			logFile, _ := cmd.Flags().GetString("log")
 			generator, _ := cmd.Flags().GetString("generator")
 			target, _ := cmd.Flags().GetString("target")
-			contexts, _ := cmd.Flags().GetStringSlice("context")
+			contexts, _ := cmd.Flags().GetStringArray("context")
+			excludeContexts, _ := cmd.Flags().GetStringArray("exclude-context")
+			allowEmpty, _ := cmd.Flags().GetBool("allow-empty")
 			load, _ := cmd.Flags().GetString("load")
 			output, _ := cmd.Flags().GetString("output")
 			jobs, _ := cmd.Flags().GetInt("jobs")
@@ -143,14 +161,16 @@ func NewRootCmd() *cobra.Command {
 			frozenPacks, _ := cmd.Flags().GetBool("frozen-packs")
 			useCbuildgen, _ := cmd.Flags().GetBool("cbuildgen")
 			targetSet, _ := cmd.Flags().GetString("active")
-			useTargetSet := cmd.Flags().Changed("active")
+			useTargetSet := utils.IsOptionSet(cmd.Flags(), "active")
 			skipConvert, _ := cmd.Flags().GetBool("skip-convert")
+			progress, _ := cmd.Flags().GetBool("progress")
+			explain, _ := cmd.Flags().GetBool("explain")
 
 			// set cbuild2cmake as default tool
 			useCbuild2CMake := !useCbuildgen
 
 			// -a option is not compatible with -c or -S
-			if useTargetSet && (len(contexts) > 0 || useContextSet) {
+			if useTargetSet && (len(contexts) > 0 || len(excludeContexts) > 0 || useContextSet) {
 				err := errutils.New(errutils.ErrInvalidTargetSetUsage)
 				log.Error(err)
 				return err
@@ -179,6 +199,8 @@ func NewRootCmd() *cobra.Command {
 				Rebuild:         rebuild,
 				UpdateRte:       updateRte,
 				Contexts:        contexts,
+				ExcludeContexts: excludeContexts,
+				AllowEmpty:      allowEmpty,
 				UseContextSet:   useContextSet,
 				Load:            load,
 				Output:          output,
@@ -188,6 +210,8 @@ func NewRootCmd() *cobra.Command {
 				TargetSet:       targetSet,
 				UseTargetSet:    useTargetSet,
 				SkipConvert:     skipConvert,
+				Progress:        progress,
+				Explain:         explain,
 			}
 
 			configs, err := utils.GetInstallConfigs()
@@ -246,7 +270,7 @@ func NewRootCmd() *cobra.Command {
 	})
 	rootCmd.SetUsageTemplate(usageTemplate)
 	rootCmd.DisableFlagsInUseLine = true
-	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.ValidArgsFunction = completeInputFile("csolution.yml", "csolution.yaml", "cprj")
 
 	rootCmd.Flags().BoolP("version", "V", false, "Print version")
 	rootCmd.Flags().BoolP("help", "h", false, "Print usage")
@@ -259,8 +283,10 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.Flags().BoolP("update-rte", "", false, "Update the RTE directory and files")
 	rootCmd.Flags().BoolP("context-set", "S", false, "Select the context names from cbuild-set.yml for generating the target application")
 	rootCmd.Flags().BoolP("frozen-packs", "", false, "Pack list and versions from cbuild-pack.yml are fixed and raises errors if it changes")
-	rootCmd.Flags().StringP("generator", "g", "Ninja", "Select build system generator")
-	rootCmd.Flags().StringSliceP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>]")
+	rootCmd.Flags().StringP("generator", "g", "Ninja", "Select build system generator [Ninja | Ninja Multi-Config | Unix Makefiles]")
+	rootCmd.Flags().StringArrayP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>], '!<name>' excludes, 're:<regex>' matches a regular expression")
+	rootCmd.Flags().StringArrayP("exclude-context", "", []string{}, "Exclude context names [<project-name>][.<build-type>][+<target-type>] from the selection")
+	rootCmd.Flags().BoolP("allow-empty", "", false, "Do not fail when the context selection matches no context")
 	rootCmd.Flags().StringP("load", "l", "required", "Set policy for packs loading [latest | all | required]")
 	rootCmd.Flags().IntP("jobs", "j", 8, "Number of job slots for parallel execution")
 	rootCmd.Flags().StringP("target", "t", "", "Optional CMake target name")
@@ -269,9 +295,12 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.PersistentFlags().BoolP("no-schema-check", "n", false, "Skip schema check")
 	rootCmd.PersistentFlags().StringP("log", "", "", "Save output messages in a log file")
 	rootCmd.PersistentFlags().StringP("toolchain", "", "", "Input toolchain to be used")
+	rootCmd.PersistentFlags().StringP("profile", "", "", "Select a profile of cbuild.config.yml")
 	rootCmd.Flags().BoolP("cbuildgen", "", false, "Generate legacy *.cprj files and use cbuildgen backend")
 	rootCmd.Flags().StringP("active", "a", "", "Select active target-set: <target-type>[@<set>]")
 	rootCmd.Flags().BoolP("skip-convert", "", false, "Skip csolution convert step")
+	rootCmd.Flags().BoolP("progress", "", false, "Show build progress per context instead of the build tool output")
+	rootCmd.Flags().BoolP("explain", "", false, "Report the reasons triggering a full rebuild")
 
 	// CPRJ specific hidden flags
 	rootCmd.Flags().StringP("intdir", "i", "", "Set directory for intermediate files")
@@ -282,7 +311,9 @@ func NewRootCmd() *cobra.Command {
 	_ = rootCmd.Flags().MarkHidden("update")
 
 	rootCmd.SetFlagErrorFunc(FlagErrorFunc)
-	rootCmd.AddCommand(build.BuildCPRJCmd, list.ListCmd, setup.SetUpCmd, zephyr.ZephyrCmd)
+	serve.Version = Version
+	rootCmd.AddCommand(analyze.AnalyzeCmd, build.BuildCPRJCmd, clean.CleanCmd, doctor.DoctorCmd, gc.GcCmd, list.ListCmd, relocate.RelocateCmd, serve.ServeCmd, setup.SetUpCmd, test.TestCmd, zephyr.ZephyrCmd)
+	registerCompletions(rootCmd)
 	return rootCmd
 }
 
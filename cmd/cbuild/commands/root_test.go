@@ -15,6 +15,7 @@ import (
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -80,6 +81,14 @@ func TestCommands(t *testing.T) {
 		assert.EqualError(err, "invalid target-set usage. The '-a' option cannot be used with the '-c' or '-S'")
 	})
 
+	t.Run("test invalid command with -a and --exclude-context", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{csolutionFile, "-a", "test", "--exclude-context", "test.Debug+CM0"})
+
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid target-set usage. The '-a' option cannot be used with the '-c' or '-S'")
+	})
+
 	t.Run("test invalid command with -a and -S", func(t *testing.T) {
 		cmd := commands.NewRootCmd()
 		cmd.SetArgs([]string{csolutionFile, "-a", "test", "-S"})
@@ -99,6 +108,30 @@ func TestCommands(t *testing.T) {
 	})
 }
 
+func TestContextOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// regular expressions may contain commas, every option takes a single item
+	cmd := commands.NewRootCmd()
+	err := cmd.ParseFlags([]string{"-c", "re:test\\.(Debug|Release){1,2}", "-c", "!test.Debug"})
+	assert.Nil(err)
+	contexts, _ := cmd.Flags().GetStringArray("context")
+	assert.Equal([]string{"re:test\\.(Debug|Release){1,2}", "!test.Debug"}, contexts)
+
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		for _, name := range []string{"context", "exclude-context"} {
+			if flag := cmd.Flags().Lookup(name); flag != nil {
+				assert.Equal("stringArray", flag.Value.Type(), cmd.CommandPath()+" --"+name)
+			}
+		}
+		for _, subCmd := range cmd.Commands() {
+			visit(subCmd)
+		}
+	}
+	visit(cmd)
+}
+
 func TestPreLogConfiguration(t *testing.T) {
 	assert := assert.New(t)
 	logDir := filepath.Join(testRoot, testDir, "log")
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package serve
+
+import (
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/server"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// Version is reported to the clients in the 'initialize' response
+var Version string
+
+func serve(cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		err := errutils.New(errutils.ErrAcceptNoArgs, "cbuild serve --help")
+		log.Error(err)
+		return err
+	}
+
+	stdio, _ := cmd.Flags().GetBool("stdio")
+	if !stdio {
+		err := errutils.New(errutils.ErrMissingTransport)
+		log.Error(err)
+		return err
+	}
+
+	configs, err := utils.GetInstallConfigs()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	s := server.New(cmd.InOrStdin(), cmd.OutOrStdout(), configs)
+	s.Version = Version
+
+	// the standard output carries the protocol messages, log output
+	// is forwarded to the client as notifications instead
+	logger := logrus.StandardLogger()
+	prevOutput := logger.Out
+	logger.SetOutput(s.LogWriter())
+	defer logger.SetOutput(prevOutput)
+
+	return s.Serve()
+}
+
+var ServeCmd = &cobra.Command{
+	Use:   "serve --stdio",
+	Short: "Run as JSON-RPC server for IDE integrations",
+	Long: "Run as JSON-RPC 2.0 server reading 'Content-Length' framed requests from standard input.\n" +
+		"Supported methods: initialize, listContexts, listToolchains, listTargetSets, listEnvironment,\n" +
+		"setup, build, clean, shutdown. Output of running requests is streamed as 'progress' notifications\n" +
+		"and requests are cancelled with '$/cancelRequest'. The 'exit' notification cancels all pending requests.",
+	RunE: serve,
+}
+
+func init() {
+	ServeCmd.DisableFlagsInUseLine = true
+	ServeCmd.Flags().BoolP("stdio", "", false, "Communicate over standard input and output")
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package serve_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("missing transport", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"serve"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("unexpected argument", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"serve", "--stdio", "test.csolution.yml"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("invalid flag", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"serve", "--invalid"})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("test help", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"serve", "-h"})
+		err := cmd.Execute()
+		assert.Nil(err)
+	})
+}
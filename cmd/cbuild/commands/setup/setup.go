@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2024-2025 Arm Limited. All rights reserved.
+ * Copyright (c) 2024-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -8,6 +8,7 @@ package setup
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
@@ -54,7 +55,9 @@ func setUpProject(cmd *cobra.Command, args []string) error {
 	logFile, _ := cmd.Flags().GetString("log")
 	generator, _ := cmd.Flags().GetString("generator")
 	target, _ := cmd.Flags().GetString("target")
-	contexts, _ := cmd.Flags().GetStringSlice("context")
+	contexts, _ := cmd.Flags().GetStringArray("context")
+	excludeContexts, _ := cmd.Flags().GetStringArray("exclude-context")
+	allowEmpty, _ := cmd.Flags().GetBool("allow-empty")
 	load, _ := cmd.Flags().GetString("load")
 	jobs, _ := cmd.Flags().GetInt("jobs")
 	output, _ := cmd.Flags().GetString("output")
@@ -71,8 +74,14 @@ func setUpProject(cmd *cobra.Command, args []string) error {
 	frozenPacks, _ := cmd.Flags().GetBool("frozen-packs")
 	useCbuildgen, _ := cmd.Flags().GetBool("cbuildgen")
 	noDatabase, _ := cmd.Flags().GetBool("no-database")
+	mergeDatabase, _ := cmd.Flags().GetString("merge-database")
+	databaseContext, _ := cmd.Flags().GetString("database-context")
+	pathMaps, _ := cmd.Flags().GetStringArray("path-map")
+	relativePaths, _ := cmd.Flags().GetBool("relative-paths")
+	vscode, _ := cmd.Flags().GetBool("vscode")
+	debugConfig, _ := cmd.Flags().GetString("debug-config")
 	targetSet, _ := cmd.Flags().GetString("active")
-	useTargetSet := cmd.Flags().Changed("active")
+	useTargetSet := utils.IsOptionSet(cmd.Flags(), "active")
 	skipConvert, _ := cmd.Flags().GetBool("skip-convert")
 
 	useCbuild2CMake := !useCbuildgen
@@ -91,6 +100,17 @@ func setUpProject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if debugConfig != "" && !slices.Contains(csolution.DebugConfigs, debugConfig) {
+		err = errutils.New(errutils.ErrInvalidDebugConfig, debugConfig, strings.Join(csolution.DebugConfigs, ", "))
+		log.Error(err)
+		return err
+	}
+
+	if _, err = utils.ParsePathMaps(pathMaps); err != nil {
+		log.Error(err)
+		return err
+	}
+
 	if len(targetSet) > 0 && targetSet[0] == '-' {
 		err = errutils.New(errutils.ErrInvalidInputArg, "-a")
 		log.Error(err)
@@ -111,6 +131,8 @@ func setUpProject(cmd *cobra.Command, args []string) error {
 		Rebuild:         rebuild,
 		UpdateRte:       updateRte,
 		Contexts:        contexts,
+		ExcludeContexts: excludeContexts,
+		AllowEmpty:      allowEmpty,
 		UseContextSet:   useContextSet,
 		Load:            load,
 		Output:          output,
@@ -118,6 +140,12 @@ func setUpProject(cmd *cobra.Command, args []string) error {
 		FrozenPacks:     frozenPacks,
 		UseCbuild2CMake: useCbuild2CMake,
 		NoDatabase:      noDatabase,
+		MergeDatabase:   mergeDatabase,
+		DatabaseContext: databaseContext,
+		PathMaps:        pathMaps,
+		RelativePaths:   relativePaths,
+		VSCode:          vscode,
+		DebugConfig:     debugConfig,
 		TargetSet:       targetSet,
 		UseTargetSet:    useTargetSet,
 		SkipConvert:     skipConvert,
@@ -211,8 +239,10 @@ func init() {
 	SetUpCmd.Flags().BoolP("update-rte", "", false, "Update the RTE directory and files")
 	SetUpCmd.Flags().BoolP("context-set", "S", false, "Select the context names from cbuild-set.yml for generating the target application")
 	SetUpCmd.Flags().BoolP("frozen-packs", "", false, "Pack list and versions from cbuild-pack.yml are fixed and raises errors if it changes")
-	SetUpCmd.Flags().StringP("generator", "g", "Ninja", "Select build system generator")
-	SetUpCmd.Flags().StringSliceP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>]")
+	SetUpCmd.Flags().StringP("generator", "g", "Ninja", "Select build system generator [Ninja | Ninja Multi-Config | Unix Makefiles]")
+	SetUpCmd.Flags().StringArrayP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>], '!<name>' excludes, 're:<regex>' matches a regular expression")
+	SetUpCmd.Flags().StringArrayP("exclude-context", "", []string{}, "Exclude context names [<project-name>][.<build-type>][+<target-type>] from the selection")
+	SetUpCmd.Flags().BoolP("allow-empty", "", false, "Do not fail when the context selection matches no context")
 	SetUpCmd.Flags().StringP("load", "l", "", "Set policy for packs loading [latest | all | required]")
 	SetUpCmd.Flags().IntP("jobs", "j", 8, "Number of job slots for parallel execution")
 	SetUpCmd.Flags().StringP("target", "t", "", "Optional CMake target name")
@@ -223,6 +253,13 @@ func init() {
 	SetUpCmd.Flags().StringP("toolchain", "", "", "Input toolchain to be used")
 	SetUpCmd.Flags().BoolP("cbuildgen", "", false, "Generate legacy *.cprj files and use cbuildgen backend")
 	SetUpCmd.Flags().BoolP("no-database", "", false, "Skip the generation of compile_commands.json files")
+	SetUpCmd.Flags().StringP("merge-database", "", "", "Merge the compile_commands.json files of the selected contexts into a single file, relative to the solution directory")
+	SetUpCmd.Flags().Lookup("merge-database").NoOptDefVal = "compile_commands.json"
+	SetUpCmd.Flags().StringP("database-context", "", "", "Context whose compile commands win for sources shared between contexts in the merged database")
+	SetUpCmd.Flags().StringArrayP("path-map", "", []string{}, "Rewrite paths in the generated compile_commands.json files, <from>=<to>")
+	SetUpCmd.Flags().BoolP("relative-paths", "", false, "Emit paths inside the solution directory as relative paths in the generated compile_commands.json files")
+	SetUpCmd.Flags().BoolP("vscode", "", false, "Generate or update .vscode/c_cpp_properties.json with one configuration per context")
+	SetUpCmd.Flags().StringP("debug-config", "", "", "Generate or update debug launch configurations from cbuild-run.yml [vscode]")
 	SetUpCmd.Flags().StringP("active", "a", "", "Select active target-set: <target-type>[@<set>]")
 	SetUpCmd.Flags().BoolP("skip-convert", "", false, "Skip csolution convert step")
 
@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2024-2025 Arm Limited. All rights reserved.
+ * Copyright (c) 2024-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -105,4 +105,17 @@ func TestSetupCommand(t *testing.T) {
 		assert.Nil(err)
 	})
 
+	t.Run("test invalid path mapping", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"setup", csolutionFile, "-a", "test", "-S=false", "-h=false", "--path-map", "/work"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid path mapping '/work', expected <from>=<to>")
+	})
+
+	t.Run("test invalid debug configuration", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"setup", csolutionFile, "--debug-config", "eclipse"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid debug configuration 'eclipse'. Supported: vscode")
+	})
 }
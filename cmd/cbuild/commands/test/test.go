@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package test
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func testSolution(cmd *cobra.Command, args []string) error {
+	var inputFile string
+	argCnt := len(args)
+	switch argCnt {
+	case 0:
+		return errutils.New(errutils.ErrRequireArg, "cbuild test --help")
+	case 1:
+		inputFile = args[0]
+	default:
+		err := errutils.New(errutils.ErrInvalidCmdLineArg)
+		log.Error(err)
+		_ = cmd.Help()
+		return err
+	}
+
+	err := utils.CheckCsolutionFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	contexts, _ := cmd.Flags().GetStringArray("context")
+	excludeContexts, _ := cmd.Flags().GetStringArray("exclude-context")
+	allowEmpty, _ := cmd.Flags().GetBool("allow-empty")
+	output, _ := cmd.Flags().GetString("output")
+	generator, _ := cmd.Flags().GetString("generator")
+	load, _ := cmd.Flags().GetString("load")
+	jobs, _ := cmd.Flags().GetInt("jobs")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	debug, _ := cmd.Flags().GetBool("debug")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	packs, _ := cmd.Flags().GetBool("packs")
+	noSchemaChk, _ := cmd.Flags().GetBool("no-schema-check")
+	toolchain, _ := cmd.Flags().GetString("toolchain")
+	logFile, _ := cmd.Flags().GetString("log")
+	noBuild, _ := cmd.Flags().GetBool("no-build")
+	runner, _ := cmd.Flags().GetString("runner")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	junitFile, _ := cmd.Flags().GetString("junit")
+	coverage, _ := cmd.Flags().GetBool("coverage")
+	coverageFormat, _ := cmd.Flags().GetString("coverage-format")
+	gcovTool, _ := cmd.Flags().GetString("gcov")
+
+	if jobs <= 0 {
+		return errutils.New(errutils.ErrInvalidNumJobs)
+	}
+	if timeout <= 0 {
+		return errutils.New(errutils.ErrInvalidInputArg, "--timeout")
+	}
+	if !slices.Contains(csolution.CoverageFormats, coverageFormat) {
+		return errutils.New(errutils.ErrUnknownCoverageFormat, coverageFormat, strings.Join(csolution.CoverageFormats, ", "))
+	}
+
+	options := builder.Options{
+		LogFile:         logFile,
+		Generator:       generator,
+		Jobs:            jobs,
+		Quiet:           quiet,
+		Debug:           debug,
+		Verbose:         verbose,
+		SchemaChk:       !noSchemaChk,
+		Packs:           packs,
+		Contexts:        contexts,
+		ExcludeContexts: excludeContexts,
+		AllowEmpty:      allowEmpty,
+		Load:            load,
+		Output:          output,
+		Toolchain:       toolchain,
+		UseCbuild2CMake: true,
+		NoBuild:         noBuild,
+		TestRunner:      runner,
+		TestTimeout:     timeout,
+		JUnitFile:       junitFile,
+		Coverage:        coverage,
+		CoverageFormat:  coverageFormat,
+		GcovTool:        gcovTool,
+	}
+
+	var configs utils.Configurations
+	if !noBuild {
+		configs, err = utils.GetInstallConfigs()
+		if err != nil {
+			return err
+		}
+	}
+
+	b := csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: utils.Runner{
+				PlainOutput: options.Debug || options.Verbose,
+			},
+			Options:        options,
+			InputFile:      inputFile,
+			InstallConfigs: configs,
+		},
+	}
+	return b.Test()
+}
+
+var TestCmd = &cobra.Command{
+	Use:   "test <name>.csolution.yml [options]",
+	Short: "Build and run the test executables of the contexts",
+	Long: "Build the contexts of a <name>.csolution.yml, run their executables and write the results as JUnit XML.\n" +
+		"Executables run on the host unless a runner command, e.g. an emulator, is given with '--runner'.\n" +
+		"The '" + csolution.ExecutablePlaceholder + "' placeholder of the runner command is replaced by the executable, " +
+		"otherwise the executable is appended. Arguments of the runner command containing spaces are quoted.\n" +
+		"Test results reported by the Unity framework are listed individually, otherwise the exit code decides.\n" +
+		"With '--coverage' the contexts are built with coverage instrumentation and a report of the sources\n" +
+		"listed in the groups of the *.cbuild.yml files is written to the output base folder.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := testSolution(cmd, args)
+		if err != nil {
+			log.Error(err)
+		}
+		return err
+	},
+}
+
+func init() {
+	TestCmd.DisableFlagsInUseLine = true
+	TestCmd.Flags().BoolP("help", "h", false, "Print usage")
+	TestCmd.Flags().BoolP("quiet", "q", false, "Suppress output messages except build invocations")
+	TestCmd.Flags().BoolP("debug", "d", false, "Enable debug messages of the cmsis build tools")
+	TestCmd.Flags().BoolP("verbose", "v", false, "Enable verbose messages from toolchain builds and print the test output")
+	TestCmd.Flags().BoolP("packs", "p", false, "Download missing software packs with cpackget")
+	TestCmd.Flags().StringP("generator", "g", "Ninja", "Select build system generator [Ninja | Ninja Multi-Config | Unix Makefiles]")
+	TestCmd.Flags().StringArrayP("context", "c", []string{}, "Input context names [<project-name>][.<build-type>][+<target-type>], '!<name>' excludes, 're:<regex>' matches a regular expression")
+	TestCmd.Flags().StringArrayP("exclude-context", "", []string{}, "Exclude context names [<project-name>][.<build-type>][+<target-type>] from the selection")
+	TestCmd.Flags().BoolP("allow-empty", "", false, "Do not fail when the context selection matches no context")
+	TestCmd.Flags().StringP("load", "l", "required", "Set policy for packs loading [latest | all | required]")
+	TestCmd.Flags().IntP("jobs", "j", 8, "Number of job slots for parallel execution")
+	TestCmd.Flags().StringP("output", "O", "", "Base folder for output files, 'outdir' and 'tmpdir' (default \"Same as '*.csolution.yml'\")")
+	TestCmd.Flags().BoolP("no-build", "", false, "Run the executables of an earlier build")
+	TestCmd.Flags().StringP("runner", "", "", "Command running the executables, e.g. 'qemu-system-arm -M mps2-an385 -nographic -semihosting -kernel "+csolution.ExecutablePlaceholder+"'")
+	TestCmd.Flags().DurationP("timeout", "", csolution.DefaultTestTimeout, "Maximum run time of a test executable")
+	TestCmd.Flags().StringP("junit", "", csolution.DefaultJUnitFile, "JUnit XML report file, relative to the output base folder")
+	TestCmd.Flags().BoolP("coverage", "", false, "Build with coverage instrumentation and collect the coverage of the application sources")
+	TestCmd.Flags().StringP("coverage-format", "", csolution.CoverageFormatLcov, "Coverage report format [lcov | cobertura]")
+	TestCmd.Flags().StringP("gcov", "", csolution.DefaultGcovTool, "gcov command extracting the coverage data, requires the '--json-format' option of GCC 9 or later, e.g. 'gcov-13'")
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package test_test
+
+import (
+	"testing"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/cmd/cbuild/commands"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoot = "../../../../test"
+const testDir = "command"
+
+func init() {
+	inittest.TestInitialization(testRoot, testDir)
+}
+
+func TestTestCommand(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := testRoot + "/" + testDir + "/TestSolution/test.csolution.yml"
+
+	t.Run("no arguments", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"test"})
+		err := cmd.Execute()
+		assert.EqualError(err, "command requires an input file argument. Run 'cbuild test --help' for more information about a command")
+	})
+
+	t.Run("multiple arguments", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"test", csolutionFile, csolutionFile})
+		err := cmd.Execute()
+		assert.Error(err)
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"test", csolutionFile, "--timeout", "0s"})
+		err := cmd.Execute()
+		assert.EqualError(err, "invalid input argument for '--timeout'")
+	})
+
+	t.Run("no test executables", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"test", csolutionFile, "--timeout", "1s", "--no-build", "-c", "test2.Debug+CM0"})
+		err := cmd.Execute()
+		assert.EqualError(err, "no test executables found in the selected contexts")
+	})
+
+	t.Run("unknown coverage format", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"test", csolutionFile, "--timeout", "1s", "--coverage", "--coverage-format", "html"})
+		err := cmd.Execute()
+		assert.EqualError(err, "unknown coverage format 'html'. Supported: lcov, cobertura")
+	})
+
+	t.Run("missing gcov", func(t *testing.T) {
+		cmd := commands.NewRootCmd()
+		cmd.SetArgs([]string{"test", csolutionFile, "--timeout", "1s", "--coverage", "--coverage-format", "lcov", "--gcov", "cbuild-missing-gcov"})
+		err := cmd.Execute()
+		assert.EqualError(err, "cbuild-missing-gcov not found, coverage requires gcov of GCC 9 or later in the PATH")
+	})
+}
@@ -11,24 +11,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
 	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
 	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
-	"github.com/hashicorp/go-version"
 )
 
-const NinjaVersion = "1.11.1"
+const NinjaVersion = builder.NinjaVersion
 
 type CbuildIdxBuilder struct {
 	builder.BuilderParams
 }
 
 func (b CbuildIdxBuilder) HasImageOnlyAndExecutes() (bool, bool) {
-	data, _ := utils.ParseCbuildIndexFile(b.InputFile)
+	data, _ := b.ParseCbuildIndexFile(b.InputFile)
 	return data.BuildIdx.ImageOnly, len(data.BuildIdx.Executes) > 0
 }
 
@@ -47,7 +45,7 @@ func (b CbuildIdxBuilder) getDirs(context string) (dirs builder.BuildDirs, err e
 	}
 
 	// parse cbuild-idx.yml file
-	data, err := utils.ParseCbuildIndexFile(b.InputFile)
+	data, err := b.ParseCbuildIndexFile(b.InputFile)
 	if err != nil {
 		return dirs, err
 	}
@@ -164,14 +162,31 @@ func (b CbuildIdxBuilder) build() error {
 		return err
 	}
 
-	if b.Options.Generator == "" {
-		b.Options.Generator = "Ninja"
-		if vars.NinjaBin == "" {
-			err = errutils.New(errutils.ErrBinaryNotFound, "ninja", "")
+	// Rebuild the contexts whose toolchain or coverage instrumentation changed since their last build
+	contexts := b.getBuildContexts()
+	coverage := b.isCoverageContext(dirs.IntDir)
+	toolchainChanged := b.cleanToolchainChangedContexts(dirs.IntDir, contexts)
+	coverageChanged := !b.Setup && b.cleanCoverageChangedContexts(dirs.IntDir, contexts, coverage)
+	if toolchainChanged || coverageChanged {
+		// regenerate the cmake files of the cleaned contexts
+		//nolint:staticcheck // intentional logic for clarity
+		_, err = b.Runner.ExecuteCommand(vars.Cbuild2cmakeBin, !(b.Options.Debug || b.Options.Verbose), args...)
+		if err != nil {
 			return err
 		}
 	}
 
+	b.Options.Generator, err = b.ResolveGenerator(vars)
+	if err != nil {
+		return err
+	}
+
+	// Reconfigure the superbuild and the contexts configured with another generator
+	builder.CleanChangedGenerator(dirs.IntDir, b.Options.Generator)
+	for _, context := range contexts {
+		builder.CleanChangedGenerator(getContextDir(dirs.IntDir, context), b.Options.Generator)
+	}
+
 	// CMake configuration command
 	args = []string{"-G", b.Options.Generator, "-S", dirs.IntDir, "-B", dirs.IntDir}
 	if b.Options.Debug {
@@ -204,27 +219,19 @@ func (b CbuildIdxBuilder) build() error {
 		buildTarget = b.Options.Target
 		args = append(args, "--target", buildTarget)
 	} else if b.Setup {
-		args = append(args, "--target", strings.ReplaceAll(b.BuildContext, " ", "_")+"-database")
+		args = append(args, "--target", utils.GetContextDirName(b.BuildContext)+"-database")
 	} else if b.ImageOnly {
 		args = append(args, "--target all")
 	} else if b.BuildContext != "" {
-		buildTarget = strings.ReplaceAll(b.BuildContext, " ", "_")
+		buildTarget = utils.GetContextDirName(b.BuildContext)
 		args = append(args, "--target", buildTarget)
 	}
 
-	//nolint:staticcheck // intentional logic for clarity
-	if b.Options.Generator == "Ninja" && !(b.Options.Debug || b.Options.Verbose) {
-		isVersionGreaterorEqual, err := b.validateNinjaVersion(NinjaVersion)
-		if err != nil {
-			return err
-		}
-
-		if isVersionGreaterorEqual {
-			args = append(args, "--", "--quiet")
-		} else {
-			log.Warn(errutils.WarnNinjaVersion)
-		}
+	buildToolArgs, err := b.getBuildToolArgs()
+	if err != nil {
+		return err
 	}
+	args = append(args, buildToolArgs...)
 
 	if !b.Setup && !b.ImageOnly {
 		// Get selected toolchain info from context specific toolchain.cmake
@@ -237,6 +244,14 @@ func (b CbuildIdxBuilder) build() error {
 		}
 	}
 
+	if coverage {
+		// Instrument the context configured by the build for coverage
+		args = getCoverageCommand(vars.CmakeBin, args)
+	}
+	if !b.Setup {
+		b.recordCoverageInfo(dirs.IntDir, contexts, coverage)
+	}
+
 	if b.Options.Debug {
 		log.Debug("cmake build command: " + vars.CmakeBin + " " + strings.Join(args, " "))
 	}
@@ -246,6 +261,11 @@ func (b CbuildIdxBuilder) build() error {
 		return err
 	}
 
+	if !b.Setup {
+		// Record the toolchain for detecting a change on the next build
+		b.recordToolchainInfo(dirs.IntDir, contexts)
+	}
+
 	isWest, westInfo := b.GetWestBuildInfo()
 	isCMake, cmakeInfo := b.GetCMakeBuildInfo()
 
@@ -287,62 +307,15 @@ func (b CbuildIdxBuilder) Clean() error {
 	return nil
 }
 
-func (b CbuildIdxBuilder) validateNinjaVersion(refVersion string) (bool, error) {
-	// Fetch installed version of ninja
-	version, err := b.getNinjaVersion()
-	if err != nil {
-		return false, err
-	}
-
-	// Compare with fixed 1.11.1 version
-	result, err := b.compareVersions(version, refVersion)
-	if err != nil {
-		return false, err
-	}
-
-	// Installed ninja version is lesser
-	if result == -1 {
-		return false, nil
-	}
-
-	// Installed version is greater or equal
-	return true, nil
-}
-
-// Retrieves ninja version
-func (b CbuildIdxBuilder) getNinjaVersion() (string, error) {
-	versionStr, err := b.Runner.ExecuteCommand("ninja", true, "--version")
-	if err != nil {
-		return "", errutils.New(errutils.ErrBinaryNotFound, "ninja", "")
-	}
-
-	re := regexp.MustCompile(`^[\d]+.[\d+]+.[\d+]`)
-	version := re.FindString(versionStr)
-	if version == "" {
-		return "", errutils.New(errutils.ErrNinjaVersionNotFound)
-	}
-	return version, nil
-}
-
-// Compare compares this version to another version. This
-// returns -1, 0, or 1 if this version is smaller, equal,
-// or larger than the other version, respectively
-// or error when invalid input
-func (b CbuildIdxBuilder) compareVersions(v1, v2 string) (int, error) {
-	version1, err := version.NewSemver(v1)
-	if err != nil {
-		return 0, err
-	}
-	version2, err := version.NewSemver(v2)
-	if err != nil {
-		return 0, err
-	}
-
-	return version1.Compare(version2), nil
+// getBuildToolArgs returns the options passed through to the build tool. The
+// quiet option is omitted when showing the build progress, as it suppresses
+// the status lines the progress is read from.
+func (b CbuildIdxBuilder) getBuildToolArgs() ([]string, error) {
+	return b.GetBuildToolOptions(!b.Options.Progress)
 }
 
 func (b CbuildIdxBuilder) HasWestBuildContext() bool {
-	cbuildIdxData, _ := utils.ParseCbuildIndexFile(b.InputFile)
+	cbuildIdxData, _ := b.ParseCbuildIndexFile(b.InputFile)
 	for _, cbuild := range cbuildIdxData.BuildIdx.Cbuilds {
 		context := cbuild.Project + cbuild.Configuration
 		if cbuild.West && (b.BuildContext == "" || b.BuildContext == context) {
@@ -355,7 +328,7 @@ func (b CbuildIdxBuilder) HasWestBuildContext() bool {
 func (b CbuildIdxBuilder) GetWestBuildInfo() (bool, []utils.WestBuildInfo) {
 	var westInfoCollection []utils.WestBuildInfo
 	basePath := filepath.Dir(b.InputFile)
-	cbuildIdxData, _ := utils.ParseCbuildIndexFile(b.InputFile)
+	cbuildIdxData, _ := b.ParseCbuildIndexFile(b.InputFile)
 	for _, cbuild := range cbuildIdxData.BuildIdx.Cbuilds {
 		if !cbuild.West {
 			continue
@@ -382,7 +355,7 @@ func (b CbuildIdxBuilder) GetWestBuildInfo() (bool, []utils.WestBuildInfo) {
 func (b CbuildIdxBuilder) GetCMakeBuildInfo() (bool, []utils.CMakeBuildInfo) {
 	var cmakeInfoCollection []utils.CMakeBuildInfo
 	basePath := filepath.Dir(b.InputFile)
-	cbuildIdxData, _ := utils.ParseCbuildIndexFile(b.InputFile)
+	cbuildIdxData, _ := b.ParseCbuildIndexFile(b.InputFile)
 	for _, cbuild := range cbuildIdxData.BuildIdx.Cbuilds {
 		if !cbuild.CMake {
 			continue
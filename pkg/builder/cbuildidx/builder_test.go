@@ -7,8 +7,10 @@
 package cbuildidx
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -16,7 +18,9 @@ import (
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
 	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	cp "github.com/otiai10/copy"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const testRoot = "../../../test"
@@ -237,12 +241,6 @@ func TestCompareVersion(t *testing.T) {
 		NOERROR = false
 	)
 
-	b := CbuildIdxBuilder{
-		builder.BuilderParams{
-			Runner: RunnerMock{},
-		},
-	}
-
 	testCases := []struct {
 		version1       string
 		version2       string
@@ -266,7 +264,7 @@ func TestCompareVersion(t *testing.T) {
 	}
 
 	for _, test := range testCases {
-		output, err := b.compareVersions(test.version1, test.version2)
+		output, err := builder.CompareVersions(test.version1, test.version2)
 		if test.expectedError && err == nil {
 			t.Errorf("Expected error, got %v", err)
 		}
@@ -291,7 +289,7 @@ func TestGetNinjaVersion(t *testing.T) {
 	}
 
 	t.Run("found ninja version", func(t *testing.T) {
-		version, err := b.getNinjaVersion()
+		version, err := b.GetNinjaVersion()
 		assert.Nil(err)
 		assert.Equal("1.10.2", version)
 	})
@@ -307,25 +305,25 @@ func TestValidateNinjaVersion(t *testing.T) {
 	}
 
 	t.Run("validate installed ninja version with outdated", func(t *testing.T) {
-		isGreaterorEqual, err := b.validateNinjaVersion("1.11.1")
+		isGreaterorEqual, err := b.ValidateNinjaVersion("1.11.1")
 		assert.Nil(err)
 		assert.False(isGreaterorEqual)
 	})
 
 	t.Run("validate installed ninja version is greater", func(t *testing.T) {
-		isGreaterorEqual, err := b.validateNinjaVersion("1.10.0")
+		isGreaterorEqual, err := b.ValidateNinjaVersion("1.10.0")
 		assert.Nil(err)
 		assert.True(isGreaterorEqual)
 	})
 
 	t.Run("validate ninja version with equal version", func(t *testing.T) {
-		isGreaterorEqual, err := b.validateNinjaVersion("1.10.2")
+		isGreaterorEqual, err := b.ValidateNinjaVersion("1.10.2")
 		assert.Nil(err)
 		assert.True(isGreaterorEqual)
 	})
 
 	t.Run("validate with invalid version", func(t *testing.T) {
-		output, err := b.validateNinjaVersion("1.10rc1")
+		output, err := b.ValidateNinjaVersion("1.10rc1")
 		assert.Error(err)
 		assert.False(output)
 	})
@@ -555,3 +553,231 @@ func TestCMakeSupportMultipleContexts(t *testing.T) {
 	assert.True(isCMake)
 	assert.Len(cmakeInfo, 2)
 }
+
+func TestToolchainChange(t *testing.T) {
+	assert := assert.New(t)
+	configs := inittest.GetTestConfigs(testRoot, testDir)
+
+	intDir := filepath.Join(testRoot, testDir, "tmp")
+	contextDir := filepath.Join(intDir, "Hello.Debug+AVH")
+	toolchainFile := filepath.Join(contextDir, "toolchain.cmake")
+	recordFile := filepath.Join(contextDir, ToolchainInfoFile)
+
+	writeToolchain := func(version string) {
+		_ = os.MkdirAll(contextDir, 0755)
+		content := "set(REGISTERED_TOOLCHAIN_ROOT \"/opt/gcc/bin\")\n" +
+			"set(REGISTERED_TOOLCHAIN_VERSION \"" + version + "\")\n" +
+			"include(\"${CMSIS_COMPILER_ROOT}/GCC.10.3.1.cmake\")\n"
+		_ = os.WriteFile(toolchainFile, []byte(content), 0600)
+	}
+
+	b := CbuildIdxBuilder{
+		builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(testRoot, testDir, "Hello.cbuild-idx.yml"),
+			Options: builder.Options{
+				Contexts: []string{"Hello.Debug+AVH"},
+				OutDir:   filepath.Join(testRoot, testDir, "OutDir"),
+			},
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+			BuildContext: "Hello.Debug+AVH",
+		},
+	}
+
+	t.Run("record toolchain after build", func(t *testing.T) {
+		_ = os.RemoveAll(contextDir)
+		writeToolchain("12.2.1")
+		err := b.Build()
+		assert.Nil(err)
+
+		info, err := utils.ReadToolchainInfo(recordFile)
+		assert.Nil(err)
+		assert.Equal(utils.ToolchainInfo{Name: "GCC", Version: "12.2.1", Root: "/opt/gcc/bin"}, info)
+	})
+
+	t.Run("unchanged toolchain keeps context", func(t *testing.T) {
+		assert.False(b.cleanToolchainChangedContexts(intDir, []string{"Hello.Debug+AVH"}))
+		_, err := os.Stat(contextDir)
+		assert.Nil(err)
+	})
+
+	t.Run("changed toolchain cleans context", func(t *testing.T) {
+		writeToolchain("13.2.1")
+		assert.True(b.cleanToolchainChangedContexts(intDir, []string{"Hello.Debug+AVH"}))
+		_, err := os.Stat(contextDir)
+		assert.True(os.IsNotExist(err))
+	})
+
+	t.Run("missing record keeps context", func(t *testing.T) {
+		writeToolchain("13.2.1")
+		assert.False(b.cleanToolchainChangedContexts(intDir, []string{"Hello.Debug+AVH"}))
+		_ = os.RemoveAll(contextDir)
+	})
+
+	t.Run("build contexts", func(t *testing.T) {
+		assert.Equal([]string{"Hello.Debug+AVH"}, b.getBuildContexts())
+	})
+}
+
+// commandCaptureRunner records the cmake build commands, cbuild2cmake generates
+// the CMakeLists.txt in the intermediate directory
+type commandCaptureRunner struct {
+	RunnerMock
+	commands *[][]string
+	intDir   string
+}
+
+func (r commandCaptureRunner) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	switch strings.TrimSuffix(filepath.Base(program), ".exe") {
+	case "cbuild2cmake":
+		_ = os.MkdirAll(r.intDir, 0755)
+		return "", os.WriteFile(filepath.Join(r.intDir, "CMakeLists.txt"), nil, 0600)
+	case "cmake":
+		if len(args) > 0 && args[0] != "-G" {
+			*r.commands = append(*r.commands, args)
+		}
+	}
+	return r.RunnerMock.ExecuteCommand(program, quiet, args...)
+}
+
+func TestCoverageChange(t *testing.T) {
+	assert := assert.New(t)
+	configs := inittest.GetTestConfigs(testRoot, testDir)
+
+	// isolated copy of the test data, the build writes into its intermediate directory
+	solutionDir := t.TempDir()
+	require.Nil(t, cp.Copy(filepath.Join(testRoot, "data"), solutionDir))
+	intDir := filepath.Join(solutionDir, "tmp")
+	contextDir := filepath.Join(intDir, "Hello.Debug+AVH")
+	markerFile := filepath.Join(contextDir, CoverageMarkerFile)
+	t.Setenv("CFLAGS", "-O0")
+	t.Setenv("CXXFLAGS", "")
+	t.Setenv("LDFLAGS", "--coverage")
+
+	writeConfiguredContext := func(toolchain string) {
+		_ = os.MkdirAll(contextDir, 0755)
+		_ = os.WriteFile(filepath.Join(contextDir, "CMakeCache.txt"), []byte("CMAKE_C_FLAGS:STRING=\n"), 0600)
+		_ = os.WriteFile(filepath.Join(contextDir, "toolchain.cmake"),
+			[]byte("set(REGISTERED_TOOLCHAIN_ROOT \"/opt/bin\")\nset(REGISTERED_TOOLCHAIN_VERSION \"6.22.0\")\n"+
+				"include(\"${CMSIS_COMPILER_ROOT}/"+toolchain+".6.22.0.cmake\")\n"), 0600)
+	}
+
+	var commands [][]string
+	b := CbuildIdxBuilder{
+		builder.BuilderParams{
+			Runner:    commandCaptureRunner{commands: &commands, intDir: intDir},
+			InputFile: filepath.Join(solutionDir, "Hello.cbuild-idx.yml"),
+			Options: builder.Options{
+				Contexts: []string{"Hello.Debug+AVH"},
+				OutDir:   filepath.Join(solutionDir, "OutDir"),
+				Coverage: true,
+			},
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+			BuildContext: "Hello.Debug+AVH",
+		},
+	}
+	contexts := []string{"Hello.Debug+AVH"}
+
+	t.Run("unconfigured context is kept", func(t *testing.T) {
+		_ = os.RemoveAll(contextDir)
+		assert.False(b.cleanCoverageChangedContexts(intDir, contexts, true))
+	})
+
+	t.Run("enabling coverage cleans configured context", func(t *testing.T) {
+		// configured by 'cbuild setup', without a recorded toolchain
+		writeConfiguredContext("GCC")
+		assert.True(b.cleanCoverageChangedContexts(intDir, contexts, true))
+		_, err := os.Stat(contextDir)
+		assert.True(os.IsNotExist(err))
+	})
+
+	t.Run("coverage build sets the flags of the build command only", func(t *testing.T) {
+		writeConfiguredContext("GCC")
+		_ = os.WriteFile(markerFile, nil, 0600)
+		commands = nil
+		err := b.Build()
+		require.Nil(t, err)
+		require.Len(t, commands, 1)
+		require.GreaterOrEqual(t, len(commands[0]), 5)
+		assert.Equal([]string{"-E", "env", "CFLAGS=-O0 --coverage", "CXXFLAGS=--coverage", "LDFLAGS=--coverage"}, commands[0][:5])
+		assert.Contains(commands[0], "--build")
+		assert.Equal("-O0", os.Getenv("CFLAGS"))
+		_, err = os.Stat(markerFile)
+		assert.Nil(err)
+	})
+
+	t.Run("unchanged coverage keeps context", func(t *testing.T) {
+		assert.False(b.cleanCoverageChangedContexts(intDir, contexts, true))
+	})
+
+	t.Run("unsupported toolchain is not instrumented", func(t *testing.T) {
+		writeConfiguredContext("AC6")
+		assert.False(b.isCoverageContext(intDir))
+		writeConfiguredContext("GCC")
+		assert.True(b.isCoverageContext(intDir))
+	})
+
+	t.Run("disabling coverage cleans context", func(t *testing.T) {
+		assert.True(b.cleanCoverageChangedContexts(intDir, contexts, false))
+		_ = os.MkdirAll(contextDir, 0755)
+		_ = os.WriteFile(markerFile, nil, 0600)
+		b.recordCoverageInfo(intDir, contexts, false)
+		_, err := os.Stat(markerFile)
+		assert.True(os.IsNotExist(err))
+		_ = os.RemoveAll(contextDir)
+	})
+}
+
+func TestProgressBuildToolArgs(t *testing.T) {
+	assert := assert.New(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("the build tool stub is a shell script")
+	}
+
+	// build tool stub printing the ninja status lines unless called with --quiet
+	buildTool := filepath.Join(t.TempDir(), "build-tool")
+	script := "#!/bin/sh\n" +
+		"for arg in \"$@\"; do [ \"$arg\" = \"--quiet\" ] && exit 0; done\n" +
+		"echo '[1/2] Building C object CMakeFiles/Hello.dir/main.c.o'\n" +
+		"echo '[2/2] Linking C executable Hello.elf'\n"
+	_ = os.WriteFile(buildTool, []byte(script), 0700) // #nosec G306 executable test stub
+
+	b := CbuildIdxBuilder{
+		builder.BuilderParams{
+			Runner: RunnerMock{},
+			Options: builder.Options{
+				Generator: builder.GeneratorNinja,
+				Progress:  true,
+			},
+		},
+	}
+
+	t.Run("progress keeps the status lines", func(t *testing.T) {
+		args, err := b.getBuildToolArgs()
+		assert.Nil(err)
+		assert.NotContains(args, "--quiet")
+
+		var out bytes.Buffer
+		runner := utils.ProgressRunner{Context: "Hello.Debug+AVH", Out: &out}
+		_, err = runner.ExecuteCommand(buildTool, false, append([]string{"--build", "."}, args...)...)
+		assert.Nil(err)
+		assert.Contains(out.String(), "Hello.Debug+AVH [==========>         ]  50%")
+		assert.Contains(out.String(), "100%")
+	})
+
+	t.Run("quiet build without progress", func(t *testing.T) {
+		b.Options.Progress = false
+		b.Options.Generator = builder.GeneratorUnixMakefiles
+		args, err := b.getBuildToolArgs()
+		assert.Nil(err)
+		assert.Equal([]string{"--", "--no-print-directory"}, args)
+	})
+}
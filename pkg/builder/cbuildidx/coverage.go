@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cbuildidx
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+const (
+	// CoverageMarkerFile marks a context configured with coverage instrumentation
+	CoverageMarkerFile = "cbuild-coverage"
+	// CoverageFlag is the compiler and linker option enabling coverage instrumentation
+	CoverageFlag = "--coverage"
+)
+
+// coverageToolchains are the toolchains supporting the coverage option
+var coverageToolchains = []string{"GCC", "CLANG"}
+
+// isConfigured reports whether CMake configured the context before
+func isConfigured(contextDir string) bool {
+	_, err := os.Stat(filepath.Join(contextDir, "CMakeCache.txt"))
+	return err == nil
+}
+
+// isCoverageContext reports whether the built context gets instrumented for
+// coverage. Coverage applies to a single context built with a toolchain
+// supporting it, never to all contexts of a build.
+func (b CbuildIdxBuilder) isCoverageContext(intDir string) bool {
+	if !b.Options.Coverage || b.BuildContext == "" {
+		return false
+	}
+	info, ok := utils.GetToolchainInfo(filepath.Join(getContextDir(intDir, b.BuildContext), "toolchain.cmake"))
+	if ok && !slices.Contains(coverageToolchains, info.Name) {
+		log.Warn("coverage is not supported by the " + info.Name + " toolchain of context \"" +
+			b.BuildContext + "\", building it without instrumentation")
+		return false
+	}
+	return true
+}
+
+// getCoverageEnv returns the flags CMake initializes the compile and link
+// options of the context from when configuring it
+func getCoverageEnv() (env []string) {
+	for _, name := range []string{"CFLAGS", "CXXFLAGS", "LDFLAGS"} {
+		flags := strings.Fields(os.Getenv(name))
+		if !slices.Contains(flags, CoverageFlag) {
+			flags = append(flags, CoverageFlag)
+		}
+		env = append(env, name+"="+strings.Join(flags, " "))
+	}
+	return env
+}
+
+// getCoverageCommand wraps the build command, which configures the context
+// on its first build, into 'cmake -E env' setting the coverage flags for the
+// child processes only
+func getCoverageCommand(cmakeBin string, args []string) []string {
+	command := append([]string{"-E", "env"}, getCoverageEnv()...)
+	command = append(command, cmakeBin)
+	return append(command, args...)
+}
+
+// cleanCoverageChangedContexts deletes the tmp directory of the configured
+// contexts whose coverage instrumentation differs from the requested one, as
+// CMake picks up the coverage flags only when configuring a context from
+// scratch. It returns true if any context was cleaned.
+func (b CbuildIdxBuilder) cleanCoverageChangedContexts(intDir string, contexts []string, coverage bool) (cleaned bool) {
+	for _, context := range contexts {
+		contextDir := getContextDir(intDir, context)
+		if !isConfigured(contextDir) {
+			continue
+		}
+		instrumented, _ := utils.FileExists(filepath.Join(contextDir, CoverageMarkerFile))
+		if instrumented == coverage {
+			continue
+		}
+		if coverage {
+			log.Info("enabling coverage for context \"" + context + "\", rebuilding context")
+		} else {
+			log.Info("disabling coverage for context \"" + context + "\", rebuilding context")
+		}
+		if err := os.RemoveAll(contextDir); err != nil {
+			log.Warn(err.Error())
+			continue
+		}
+		cleaned = true
+	}
+	return cleaned
+}
+
+// recordCoverageInfo marks the contexts configured with coverage instrumentation.
+// It is called before the build, which configures the contexts with the
+// requested flags, so that the marker is right also after a failed build.
+func (b CbuildIdxBuilder) recordCoverageInfo(intDir string, contexts []string, coverage bool) {
+	for _, context := range contexts {
+		contextDir := getContextDir(intDir, context)
+		if _, err := os.Stat(contextDir); err != nil {
+			continue
+		}
+		marker := filepath.Join(contextDir, CoverageMarkerFile)
+		var err error
+		if coverage {
+			err = os.WriteFile(marker, nil, 0600)
+		} else if _, statErr := os.Stat(marker); statErr == nil {
+			err = os.Remove(marker)
+		}
+		if err != nil {
+			log.Warn(err.Error())
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cbuildidx
+
+import (
+	"os"
+	"path/filepath"
+
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+// ToolchainInfoFile records the toolchain used for the last build of a context
+const ToolchainInfoFile = "cbuild-toolchain.yml"
+
+// getBuildContexts returns the contexts processed by the build
+func (b CbuildIdxBuilder) getBuildContexts() (contexts []string) {
+	if b.BuildContext != "" {
+		return []string{b.BuildContext}
+	}
+	data, err := b.ParseCbuildIndexFile(b.InputFile)
+	if err != nil {
+		return nil
+	}
+	for _, cbuild := range data.BuildIdx.Cbuilds {
+		contexts = append(contexts, cbuild.Project+cbuild.Configuration)
+	}
+	return contexts
+}
+
+// getContextDir returns the context specific directory under the tmp directory
+func getContextDir(intDir string, context string) string {
+	return filepath.Join(intDir, utils.GetContextDirName(context))
+}
+
+// cleanToolchainChangedContexts deletes the tmp directory of the contexts whose
+// toolchain differs from the one recorded at their last build, so that no
+// objects of the former toolchain are reused. It returns true if any context
+// was cleaned.
+func (b CbuildIdxBuilder) cleanToolchainChangedContexts(intDir string, contexts []string) (cleaned bool) {
+	for _, context := range contexts {
+		contextDir := getContextDir(intDir, context)
+		current, ok := utils.GetToolchainInfo(filepath.Join(contextDir, "toolchain.cmake"))
+		if !ok {
+			continue
+		}
+		recorded, err := utils.ReadToolchainInfo(filepath.Join(contextDir, ToolchainInfoFile))
+		if err != nil || recorded == current {
+			continue
+		}
+		log.Info("toolchain of context \"" + context + "\" changed from " + recorded.String() +
+			" to " + current.String() + ", rebuilding context")
+		if err := os.RemoveAll(contextDir); err != nil {
+			log.Warn(err.Error())
+			continue
+		}
+		cleaned = true
+	}
+	return cleaned
+}
+
+// recordToolchainInfo stores the toolchain used for building the contexts
+func (b CbuildIdxBuilder) recordToolchainInfo(intDir string, contexts []string) {
+	for _, context := range contexts {
+		contextDir := getContextDir(intDir, context)
+		info, ok := utils.GetToolchainInfo(filepath.Join(contextDir, "toolchain.cmake"))
+		if !ok {
+			continue
+		}
+		if err := utils.WriteToolchainInfo(filepath.Join(contextDir, ToolchainInfoFile), info); err != nil {
+			log.Warn(err.Error())
+		}
+	}
+}
@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2022-2024 Arm Limited. All rights reserved.
+ * Copyright (c) 2022-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -217,14 +217,13 @@ func (b CprjBuilder) build() error {
 		return err
 	}
 
-	if b.Options.Generator == "" {
-		b.Options.Generator = "Ninja"
-		if vars.NinjaBin == "" {
-			err = errutils.New(errutils.ErrBinaryNotFound, "ninja", "")
-			return err
-		}
+	b.Options.Generator, err = b.ResolveGenerator(vars)
+	if err != nil {
+		return err
 	}
 
+	builder.CleanChangedGenerator(dirs.IntDir, b.Options.Generator)
+
 	args = []string{"-G", b.Options.Generator, "-S", dirs.IntDir, "-B", dirs.IntDir}
 	if b.Options.Debug {
 		args = append(args, "-Wdev")
@@ -250,9 +249,11 @@ func (b CprjBuilder) build() error {
 	if b.Options.Target != "" {
 		args = append(args, "--target", b.Options.Target)
 	}
-	if b.Options.Debug || b.Options.Verbose {
-		args = append(args, "--verbose")
+	buildToolArgs, err := b.GetBuildToolOptions(b.Options.Quiet)
+	if err != nil {
+		return err
 	}
+	args = append(args, buildToolArgs...)
 
 	if b.Options.Debug {
 		log.Debug("cmake build command: " + vars.CmakeBin + " " + strings.Join(args, " "))
@@ -43,6 +43,9 @@ func (r RunnerMock) ExecuteCommand(program string, quiet bool, args ...string) (
 	} else if strings.Contains(program, "cpackget") {
 	} else if strings.Contains(program, "cmake") {
 	} else if strings.Contains(program, "ninja") {
+		if args[0] == "--version" {
+			return "1.11.1", nil
+		}
 	} else if strings.Contains(program, "xmllint") {
 	} else {
 		return "", errutils.New(errutils.ErrInvalidCommand, program)
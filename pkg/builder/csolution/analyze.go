@@ -0,0 +1,293 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+const (
+	AnalyzerClangTidy = "clang-tidy"
+	AnalyzerCppcheck  = "cppcheck"
+	FormatSARIF       = "sarif"
+)
+
+// Analyzers lists the supported static analysis tools
+var Analyzers = []string{AnalyzerClangTidy, AnalyzerCppcheck}
+
+// Finding is a diagnostic reported by a static analysis tool
+type Finding struct {
+	Context  string `json:"context"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Check    string `json:"check,omitempty"`
+}
+
+// analyzeJob is the analysis of a source file of a context
+type analyzeJob struct {
+	context         string
+	compileCommands string
+	file            string
+}
+
+// findingRegex matches the diagnostics of clang-tidy and of cppcheck using the
+// template set in getAnalyzerArgs: '<file>:<line>:<column>: <severity>: <message> [<check>]'
+var findingRegex = regexp.MustCompile(`^(.+?):(\d+):(\d+): (\w+): (.*?)(?: \[([^\]]+)\])?$`)
+
+// cppcheckTemplate formats the cppcheck findings like the clang-tidy diagnostics
+const cppcheckTemplate = "{file}:{line}:{column}: {severity}: {message} [{id}]"
+
+// parseFindings extracts the findings of the analyzer output
+func parseFindings(output string, context string) (findings []Finding) {
+	for _, line := range strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		matches := findingRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil || matches[4] == "note" {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		findings = append(findings, Finding{
+			Context:  context,
+			File:     filepath.ToSlash(filepath.Clean(matches[1])),
+			Line:     lineNumber,
+			Column:   column,
+			Severity: matches[4],
+			Message:  matches[5],
+			Check:    matches[6],
+		})
+	}
+	return findings
+}
+
+// getAnalyzerArgs returns the arguments analyzing a source file with the compile
+// commands of its context
+func getAnalyzerArgs(analyzer string, job analyzeJob, outputFile string) []string {
+	if analyzer == AnalyzerCppcheck {
+		return []string{"--project=" + job.compileCommands, "--file-filter=" + job.file,
+			"--quiet", "--enable=warning,style,performance,portability",
+			"--template=" + cppcheckTemplate, "--output-file=" + outputFile}
+	}
+	return []string{"-p", filepath.Dir(job.compileCommands), "--quiet", job.file}
+}
+
+// getProjectSources returns the absolute paths of the source files listed in the
+// groups of the cbuild file, i.e. the sources of the project and not of packs
+func getProjectSources(cbuildFile string) (sources map[string]bool, err error) {
+	cbuild, err := utils.ParseCbuildFile(cbuildFile)
+	if err != nil {
+		return nil, err
+	}
+	sources = make(map[string]bool)
+	for _, file := range utils.GetGroupFiles(cbuild.Build.Groups) {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(cbuildFile), file)
+		}
+		sources[filepath.ToSlash(filepath.Clean(file))] = true
+	}
+	return sources, nil
+}
+
+// getAnalyzeJobs returns the source files of the selected contexts to analyze
+func (b CSolutionBuilder) getAnalyzeJobs() (jobs []analyzeJob, err error) {
+	idxFile, cbuildFiles, contexts, err := b.getSelectedCbuildFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, context := range contexts {
+		outDir, err := utils.GetOutDir(idxFile, context)
+		if err != nil {
+			return nil, err
+		}
+		compileCommandsFile, _ := filepath.Abs(filepath.Join(outDir, "compile_commands.json"))
+		if _, err := os.Stat(compileCommandsFile); err != nil {
+			log.Warn("compile_commands.json of context '" + context + "' not found, run 'cbuild setup' first")
+			continue
+		}
+		commands, err := utils.ParseCompileCommandsFile(compileCommandsFile)
+		if err != nil {
+			return nil, err
+		}
+		var sources map[string]bool
+		if !b.Options.IncludePacks {
+			if sources, err = getProjectSources(cbuildFiles[context]); err != nil {
+				return nil, err
+			}
+		}
+		for _, command := range commands {
+			file := command.File
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(command.Directory, file)
+			}
+			file = filepath.ToSlash(filepath.Clean(file))
+			if sources != nil && !sources[file] {
+				continue
+			}
+			jobs = append(jobs, analyzeJob{context: context, compileCommands: compileCommandsFile, file: file})
+		}
+	}
+	if len(jobs) == 0 {
+		return nil, errutils.New(errutils.ErrNoSourcesToAnalyze)
+	}
+	return jobs, nil
+}
+
+// runAnalyzer analyzes a source file and returns its findings. The standard error
+// of the analyzer is kept apart, so that the diagnostics of parallel jobs do not
+// interleave with the printed findings. A failure of the analyzer without
+// findings is returned as error.
+func (b CSolutionBuilder) runAnalyzer(analyzerBin string, job analyzeJob) ([]Finding, error) {
+	var outputFile string
+	if b.Options.Analyzer == AnalyzerCppcheck {
+		file, err := os.CreateTemp("", "cbuild-cppcheck-*.txt")
+		if err == nil {
+			outputFile = file.Name()
+			file.Close()
+			defer os.Remove(outputFile)
+		}
+	}
+	output, stderr, err := utils.ExecuteCommandOutput(b.Runner, analyzerBin, getAnalyzerArgs(b.Options.Analyzer, job, outputFile)...)
+	if outputFile != "" {
+		if data, readErr := os.ReadFile(outputFile); readErr == nil {
+			output += "\n" + string(data)
+		}
+	}
+	findings := parseFindings(output, job.context)
+	stderr = strings.TrimSpace(stderr)
+	if err != nil && len(findings) == 0 {
+		message := b.Options.Analyzer + " failed on " + job.file + ": " + err.Error()
+		if stderr != "" {
+			message += "\n" + stderr
+		}
+		return nil, errors.New(message)
+	}
+	if stderr != "" {
+		log.Debug(b.Options.Analyzer + " on " + job.file + ":\n" + stderr)
+	}
+	return findings, nil
+}
+
+// Analyze runs the static analysis tool over the sources of the selected contexts
+// in parallel and prints the findings. The findings are printed even when the
+// analyzer failed on some files, the failures are returned as error.
+func (b CSolutionBuilder) Analyze() error {
+	analyzerBin, err := exec.LookPath(b.Options.Analyzer)
+	if err != nil {
+		return errutils.New(errutils.ErrAnalyzerNotFound, b.Options.Analyzer)
+	}
+	jobs, err := b.getAnalyzeJobs()
+	if err != nil {
+		return err
+	}
+	if b.Options.Format == FormatText {
+		log.Info("Analyzing " + strconv.Itoa(len(jobs)) + " file(s) with " + b.Options.Analyzer)
+	}
+
+	workers := b.Options.Jobs
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([][]Finding, len(jobs))
+	jobErrors := make([]error, len(jobs))
+	semaphore := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, job analyzeJob) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i], jobErrors[i] = b.runAnalyzer(analyzerBin, job)
+		}(i, job)
+	}
+	wg.Wait()
+
+	var failures []string
+	for _, jobErr := range jobErrors {
+		if jobErr != nil {
+			failures = append(failures, jobErr.Error())
+		}
+	}
+
+	// machine-readable formats keep the standard output free of diagnostics
+	for _, failure := range failures {
+		if b.Options.Format == FormatText {
+			log.Warn(failure)
+		} else {
+			log.Debug(failure)
+		}
+	}
+
+	findings := mergeFindings(results)
+	switch b.Options.Format {
+	case FormatJSON:
+		err = printJSON(findings)
+	case FormatSARIF:
+		err = printJSON(getSARIF(b.Options.Analyzer, findings, failures))
+	default:
+		printFindings(findings)
+	}
+	if err == nil && len(failures) > 0 {
+		err = errutils.New(errutils.ErrAnalyzerFailed, b.Options.Analyzer, len(failures))
+	}
+	return err
+}
+
+// mergeFindings removes the duplicates of findings reported for shared headers
+// and sorts them by file and location
+func mergeFindings(results [][]Finding) []Finding {
+	findings := []Finding{}
+	seen := make(map[Finding]bool)
+	for _, result := range results {
+		for _, finding := range result {
+			if seen[finding] {
+				continue
+			}
+			seen[finding] = true
+			findings = append(findings, finding)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings
+}
+
+func printFindings(findings []Finding) {
+	files := make(map[string]bool)
+	for _, finding := range findings {
+		line := fmt.Sprintf("%s:%d:%d: %s: %s", finding.File, finding.Line, finding.Column, finding.Severity, finding.Message)
+		if finding.Check != "" {
+			line += " [" + finding.Check + "]"
+		}
+		utils.LogStdMsg(line)
+		files[finding.File] = true
+	}
+	utils.LogStdMsg(fmt.Sprintf("%d finding(s) in %d file(s)", len(findings), len(files)))
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// analyzerMock reports a finding for each analyzed file on the standard output
+// and the diagnostics of the analyzer on the standard error, it fails for the
+// files containing 'fail'
+type analyzerMock struct {
+	mutex *sync.Mutex
+	files *[]string
+}
+
+func (r analyzerMock) ExecuteCommand(program string, _ bool, args ...string) (string, error) {
+	output, _, err := r.ExecuteCommandOutput(program, args...)
+	return output, err
+}
+
+func (r analyzerMock) ExecuteCommandOutput(program string, args ...string) (string, string, error) {
+	var file, outputFile string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--file-filter=") {
+			file = strings.TrimPrefix(arg, "--file-filter=")
+		} else if strings.HasPrefix(arg, "--output-file=") {
+			outputFile = strings.TrimPrefix(arg, "--output-file=")
+		}
+	}
+	if file == "" {
+		file = args[len(args)-1]
+	}
+	r.mutex.Lock()
+	*r.files = append(*r.files, file)
+	r.mutex.Unlock()
+	if strings.Contains(file, "fail") {
+		return "", "error: unable to handle compilation\n", errors.New("exit status 1")
+	}
+
+	output := file + ":3:5: warning: unused variable 'x' [clang-diagnostic-unused-variable]\n" +
+		file + ":3:5: note: declared here\n"
+	stderr := "1 warning generated.\n"
+	if outputFile != "" {
+		return "Checking " + file + " ...\n", stderr, os.WriteFile(outputFile, []byte(file+":7:1: error: Null pointer dereference [nullPointer]\n"), 0600)
+	}
+	return output, stderr, nil
+}
+
+func TestParseFindings(t *testing.T) {
+	assert := assert.New(t)
+	output := "/src/main.c:12:5: warning: unused variable 'x' [clang-diagnostic-unused-variable]\r\n" +
+		"/src/main.c:10:1: note: previous declaration\n" +
+		"C:/src/util.c:3:1: error: Null pointer dereference [nullPointer]\n" +
+		"/src/util.h:1:1: style: missing guard\n" +
+		"2 warnings generated.\n"
+	assert.Equal([]Finding{
+		{Context: "test.Debug+CM0", File: "/src/main.c", Line: 12, Column: 5, Severity: "warning", Message: "unused variable 'x'", Check: "clang-diagnostic-unused-variable"},
+		{Context: "test.Debug+CM0", File: "C:/src/util.c", Line: 3, Column: 1, Severity: "error", Message: "Null pointer dereference", Check: "nullPointer"},
+		{Context: "test.Debug+CM0", File: "/src/util.h", Line: 1, Column: 1, Severity: "style", Message: "missing guard"},
+	}, parseFindings(output, "test.Debug+CM0"))
+}
+
+func TestGetSARIF(t *testing.T) {
+	assert := assert.New(t)
+	findings := []Finding{
+		{File: "/src/main file.c", Line: 12, Column: 5, Severity: "warning", Message: "unused", Check: "unused-variable"},
+		{File: "C:/src/util.c", Line: 3, Severity: "error", Message: "null", Check: "nullPointer"},
+		{File: "src/util.h", Line: 1, Severity: "note", Message: "guard"},
+	}
+	sarif := getSARIF(AnalyzerCppcheck, findings, nil)
+	assert.Equal(SARIFVersion, sarif.Version)
+	run := sarif.Runs[0]
+	assert.Equal("cppcheck", run.Tool.Driver.Name)
+	assert.Equal([]SARIFInvocation{{ExecutionSuccessful: true}}, run.Invocations)
+	assert.Equal([]SARIFRule{{ID: "nullPointer"}, {ID: "unused-variable"}}, run.Tool.Driver.Rules)
+	assert.Equal("file:///src/main%20file.c", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal("file:///C:/src/util.c", run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal("src/util.h", run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal([]string{"warning", "error", "note"}, []string{run.Results[0].Level, run.Results[1].Level, run.Results[2].Level})
+	assert.Equal(SARIFRegion{StartLine: 12, StartColumn: 5}, run.Results[0].Locations[0].PhysicalLocation.Region)
+
+	sarif = getSARIF(AnalyzerCppcheck, nil, []string{"cppcheck failed on /src/main.c: exit status 1"})
+	assert.Equal([]SARIFInvocation{{ExecutionSuccessful: false, ToolExecutionNotifications: []SARIFNotification{
+		{Level: "error", Message: SARIFMessage{Text: "cppcheck failed on /src/main.c: exit status 1"}},
+	}}}, sarif.Runs[0].Invocations)
+	assert.Empty(sarif.Runs[0].Results)
+}
+
+func TestAnalyze(t *testing.T) {
+	assert := assert.New(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("tools in the PATH are shell scripts")
+	}
+
+	pathDir := t.TempDir()
+	for _, tool := range Analyzers {
+		//nolint:gosec // G306: executable permissions required for test binary
+		_ = os.WriteFile(filepath.Join(pathDir, tool), []byte("#!/usr/bin/env bash\n"), 0755)
+	}
+	t.Setenv("PATH", pathDir)
+
+	solutionDir := t.TempDir()
+	packDir := filepath.ToSlash(t.TempDir())
+	srcDir := filepath.ToSlash(solutionDir)
+	database := `[
+  {"directory": "` + srcDir + `/out", "file": "` + srcDir + `/src/main.c", "command": "gcc -c main.c"},
+  {"directory": "` + srcDir + `", "file": "src/util.c", "command": "gcc -c src/util.c"},
+  {"directory": "` + srcDir + `/out", "file": "` + packDir + `/startup.c", "command": "gcc -c startup.c"}
+]`
+	files := map[string]string{
+		"test.csolution.yml":        "solution:\n",
+		"test.cbuild-idx.yml":       "build-idx:\n  cbuilds:\n    - cbuild: test.Debug+CM0.cbuild.yml\n      project: test\n      configuration: .Debug+CM0\n",
+		"test.Debug+CM0.cbuild.yml": "build:\n  output-dirs:\n    outdir: out\n  groups:\n    - group: Source\n      files:\n        - file: ./src/main.c\n      groups:\n        - group: Util\n          files:\n            - file: src/util.c\n",
+		"out/compile_commands.json": database,
+	}
+	for file, content := range files {
+		path := filepath.Join(solutionDir, file)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0600)
+	}
+
+	var analyzed []string
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    analyzerMock{mutex: &sync.Mutex{}, files: &analyzed},
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+			Options:   builder.Options{Analyzer: AnalyzerClangTidy, Format: FormatText, Jobs: 2},
+		},
+	}
+
+	run := func() string {
+		analyzed = nil
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		assert.Nil(b.Analyze())
+		return buf.String()
+	}
+
+	t.Run("project sources", func(t *testing.T) {
+		out := run()
+		assert.ElementsMatch([]string{srcDir + "/src/main.c", srcDir + "/src/util.c"}, analyzed)
+		assert.Contains(out, srcDir+"/src/main.c:3:5: warning: unused variable 'x' [clang-diagnostic-unused-variable]\n")
+		assert.NotContains(out, "note:")
+		assert.Contains(out, "2 finding(s) in 2 file(s)\n")
+	})
+
+	t.Run("include packs", func(t *testing.T) {
+		b.Options.IncludePacks = true
+		defer func() { b.Options.IncludePacks = false }()
+		run()
+		assert.Equal(3, len(analyzed))
+		assert.Contains(analyzed, packDir+"/startup.c")
+	})
+
+	t.Run("cppcheck json", func(t *testing.T) {
+		b.Options.Analyzer = AnalyzerCppcheck
+		b.Options.Format = FormatJSON
+		defer func() {
+			b.Options.Analyzer = AnalyzerClangTidy
+			b.Options.Format = FormatText
+		}()
+		out := run()
+		var findings []Finding
+		assert.Nil(json.Unmarshal([]byte(out), &findings))
+		assert.Equal([]Finding{
+			{Context: "test.Debug+CM0", File: srcDir + "/src/main.c", Line: 7, Column: 1, Severity: "error", Message: "Null pointer dereference", Check: "nullPointer"},
+			{Context: "test.Debug+CM0", File: srcDir + "/src/util.c", Line: 7, Column: 1, Severity: "error", Message: "Null pointer dereference", Check: "nullPointer"},
+		}, findings)
+	})
+
+	t.Run("failing analyzer sarif", func(t *testing.T) {
+		b.Options.Format = FormatSARIF
+		b.Options.IncludePacks = true
+		databaseFile := filepath.Join(solutionDir, "out", "compile_commands.json")
+		failing := strings.Replace(database, "/startup.c", "/fail.c", 1)
+		_ = os.WriteFile(databaseFile, []byte(failing), 0600)
+		defer func() {
+			b.Options.Format = FormatText
+			b.Options.IncludePacks = false
+			_ = os.WriteFile(databaseFile, []byte(database), 0600)
+		}()
+
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		err := b.Analyze()
+		assert.EqualError(err, "clang-tidy failed on 1 file(s)")
+
+		var sarif SARIFLog
+		assert.Nil(json.Unmarshal(buf.Bytes(), &sarif))
+		invocation := sarif.Runs[0].Invocations[0]
+		assert.False(invocation.ExecutionSuccessful)
+		assert.Equal("clang-tidy failed on "+packDir+"/fail.c: exit status 1\nerror: unable to handle compilation",
+			invocation.ToolExecutionNotifications[0].Message.Text)
+		assert.Len(sarif.Runs[0].Results, 2)
+	})
+
+	t.Run("missing database", func(t *testing.T) {
+		_ = os.Remove(filepath.Join(solutionDir, "out", "compile_commands.json"))
+		err := b.Analyze()
+		assert.EqualError(err, "no source files to analyze, run 'cbuild setup' to generate the compile_commands.json files")
+	})
+
+	t.Run("missing analyzer", func(t *testing.T) {
+		t.Setenv("PATH", solutionDir)
+		err := b.Analyze()
+		assert.EqualError(err, "clang-tidy not found, install it and add it to the PATH")
+	})
+}
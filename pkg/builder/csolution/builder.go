@@ -79,12 +79,27 @@ func (b CSolutionBuilder) formulateArgs(command []string) (args []string) {
 	return
 }
 
+// getToolchainsEnv returns the environment registering the toolchains of the
+// user-level and solution-level toolchains.yml for csolution, cbuild2cmake and cmake
+func (b CSolutionBuilder) getToolchainsEnv() ([]string, error) {
+	var solutionDir string
+	if b.InputFile != "" {
+		solutionDir = filepath.Dir(b.InputFile)
+	}
+	return utils.GetToolchainsEnv(solutionDir)
+}
+
 func (b CSolutionBuilder) runCSolution(args []string, quiet bool) (output string, err error) {
 	csolutionBin, err := b.getCSolutionPath()
 	if err != nil {
 		return
 	}
 
+	env, err := b.getToolchainsEnv()
+	if err != nil {
+		return
+	}
+
 	if quiet {
 		args = slices.DeleteFunc(args, func(arg string) bool { return arg == "--verbose" })
 	}
@@ -92,7 +107,7 @@ func (b CSolutionBuilder) runCSolution(args []string, quiet bool) (output string
 	log.Debug("csolution command: csolution " + strings.Join(args, " "))
 
 	// run csolution with args
-	output, err = b.Runner.ExecuteCommand(csolutionBin, quiet, args...)
+	output, err = utils.WithEnv(b.Runner, env).ExecuteCommand(csolutionBin, quiet, args...)
 	return
 }
 
@@ -171,8 +186,12 @@ func (b CSolutionBuilder) generateBuildFiles() (err error) {
 		if err != nil {
 			return
 		}
+		var env []string
+		if env, err = b.getToolchainsEnv(); err != nil {
+			return
+		}
 		log.Debug("csolution command: csolution " + strings.Join(args, " "))
-		_, stdErr, err = utils.ExecuteCommand(csolutionBin, args...)
+		_, stdErr, err = utils.ExecuteCommandWithEnv(env, csolutionBin, args...)
 	} else {
 		//nolint:staticcheck // intentional logic for clarity
 		_, convertErr := b.runCSolution(args, !b.Options.Debug && !b.Options.Verbose)
@@ -186,7 +205,7 @@ func (b CSolutionBuilder) generateBuildFiles() (err error) {
 			return err
 		}
 
-		data, err := utils.ParseCbuildIndexFile(idxFile)
+		data, err := b.ParseCbuildIndexFile(idxFile)
 		if err != nil {
 			return err
 		}
@@ -273,7 +292,7 @@ func (b CSolutionBuilder) generateBuildFiles() (err error) {
 
 func (b CSolutionBuilder) getCprjFilePath(idxFile string, context string) (string, error) {
 	var cprjPath string
-	data, err := utils.ParseCbuildIndexFile(idxFile)
+	data, err := b.ParseCbuildIndexFile(idxFile)
 	if err == nil {
 		var path string
 		for _, cbuild := range data.BuildIdx.Cbuilds {
@@ -304,7 +323,7 @@ func (b CSolutionBuilder) getSelectedContexts(filePath string) ([]string, error)
 		}
 		retErr = err
 	} else {
-		data, err := utils.ParseCbuildIndexFile(filePath)
+		data, err := b.ParseCbuildIndexFile(filePath)
 		if err == nil {
 			for _, cbuild := range data.BuildIdx.Cbuilds {
 				contexts = append(contexts, cbuild.Project+cbuild.Configuration)
@@ -368,8 +387,22 @@ func (b CSolutionBuilder) getProjsBuilders(selectedContexts []string) (projBuild
 		return nil, err
 	}
 
+	// Pass the registered toolchains to cbuild2cmake and cmake
+	env, err := b.getToolchainsEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	var projBuilder builder.IBuilderInterface
 	for _, context := range selectedContexts {
+		runner := b.Runner
+		//nolint:staticcheck // intentional logic for clarity
+		if b.Options.Progress && !(b.Options.Debug || b.Options.Verbose) {
+			// condense the build output into a progress line per context
+			runner = utils.ProgressRunner{Context: context, LogFile: b.Options.LogFile}
+		}
+		runner = utils.WithEnv(runner, env)
+
 		infoMsg := "Retrieve build information for context: \"" + context + "\""
 		log.Info(infoMsg)
 
@@ -383,12 +416,13 @@ func (b CSolutionBuilder) getProjsBuilders(selectedContexts []string) (projBuild
 			// Create a builder for cbuild2CMake
 			projBuilder = cbuildidx.CbuildIdxBuilder{
 				BuilderParams: builder.BuilderParams{
-					Runner:         b.Runner,
+					Runner:         runner,
 					Options:        buildOptions,
 					InputFile:      idxFile,
 					InstallConfigs: b.InstallConfigs,
 					Setup:          b.Setup,
 					BuildContext:   context,
+					IdxCache:       b.IdxCache,
 				},
 			}
 		} else {
@@ -401,7 +435,7 @@ func (b CSolutionBuilder) getProjsBuilders(selectedContexts []string) (projBuild
 			// Create a builder for cproject
 			projBuilder = cproject.CprjBuilder{
 				BuilderParams: builder.BuilderParams{
-					Runner:         b.Runner,
+					Runner:         runner,
 					Options:        buildOptions,
 					InputFile:      cprjFile,
 					InstallConfigs: b.InstallConfigs,
@@ -501,43 +535,10 @@ func (b CSolutionBuilder) listToolchains(quiet bool) (toolchains []string, err e
 	return toolchains, nil
 }
 
-func (b CSolutionBuilder) listEnvironment(quiet bool) (envConfigs []string, err error) {
-	// get installed exe path and version number
-	getInstalledExeInfo := func(name string) string {
-		path, err := utils.GetInstalledExePath(name)
-		if err != nil || path == "" {
-			return "<Not Found>"
-		}
-
-		// run "exe --version" command
-		versionStr, err := b.Runner.ExecuteCommand(path, true, "--version")
-		if err != nil {
-			versionStr = ""
-		}
-
-		// get version
-		var version string
-		if name == "cmake" {
-			regex := "version\\s(.*?)\\s"
-			re, err := regexp.Compile(regex)
-			if err == nil {
-				match := re.FindAllStringSubmatch(versionStr, 1)
-				for index := range match {
-					version = match[index][1]
-					break
-				}
-			}
-		} else {
-			version = versionStr
-		}
-		info := path
-		if version != "" {
-			info += ", version " + version
-		}
-		return info
-	}
+// environmentTools are the tools reported in addition to the csolution environment
+var environmentTools = []string{"cmake", "ninja"}
 
-	// step1: call csolution list environment
+func (b CSolutionBuilder) listCSolutionEnvironment(quiet bool) (envConfigs []string, err error) {
 	args := []string{"list", "environment"}
 	output, err := b.runCSolution(args, quiet)
 	if err != nil {
@@ -546,25 +547,50 @@ func (b CSolutionBuilder) listEnvironment(quiet bool) (envConfigs []string, err
 	if output != "" {
 		envConfigs = strings.Split(strings.ReplaceAll(strings.TrimSpace(output), "\r\n", "\n"), "\n")
 	}
+	return envConfigs, nil
+}
 
-	// step2: add other environment info
-	envConfigs = append(envConfigs, "cmake="+getInstalledExeInfo("cmake"))
-	envConfigs = append(envConfigs, "ninja="+getInstalledExeInfo("ninja"))
+func (b CSolutionBuilder) listEnvironment(quiet bool) (envConfigs []string, err error) {
+	// step1: call csolution list environment
+	envConfigs, err = b.listCSolutionEnvironment(quiet)
+	if err != nil {
+		return
+	}
 
+	// step2: add other environment info
+	for _, tool := range environmentTools {
+		toolInfo := b.getToolInfo(tool)
+		info := toolInfo.Path
+		if info == "" {
+			info = "<Not Found>"
+		} else if toolInfo.Version != "" {
+			info += ", version " + toolInfo.Version
+		}
+		envConfigs = append(envConfigs, tool+"="+info)
+	}
 	return envConfigs, nil
 }
 
 func (b CSolutionBuilder) ListContexts() error {
+	if b.Options.Format == FormatJSON {
+		return b.listContextsJSON()
+	}
 	_, err := b.listContexts(false, false)
 	return err
 }
 
 func (b CSolutionBuilder) ListToolchains() error {
+	if b.Options.Format == FormatJSON {
+		return b.listToolchainsJSON()
+	}
 	_, err := b.listToolchains(false)
 	return err
 }
 
 func (b CSolutionBuilder) ListEnvironment() error {
+	if b.Options.Format == FormatJSON {
+		return b.listEnvironmentJSON()
+	}
 	envConfigs, err := b.listEnvironment(true)
 	if err != nil {
 		return err
@@ -575,6 +601,31 @@ func (b CSolutionBuilder) ListEnvironment() error {
 	return nil
 }
 
+// GetContexts returns the contexts of the solution without printing them
+func (b CSolutionBuilder) GetContexts() ([]string, error) {
+	return b.listContexts(true, false)
+}
+
+// GetToolchains returns the registered or solution supported toolchains without printing them
+func (b CSolutionBuilder) GetToolchains() ([]string, error) {
+	return b.listToolchains(true)
+}
+
+// GetTargetSets returns the target-sets of the solution without printing them
+func (b CSolutionBuilder) GetTargetSets() ([]string, error) {
+	return b.listTargetSets(true)
+}
+
+// GetEnvironment returns the environment configurations without printing them
+func (b CSolutionBuilder) GetEnvironment() ([]string, error) {
+	return b.listEnvironment(true)
+}
+
+// GetIdxFilePath returns the path of the existing *.cbuild-idx.yml file of the solution
+func (b CSolutionBuilder) GetIdxFilePath() (string, error) {
+	return b.getIdxFilePath()
+}
+
 func (b CSolutionBuilder) build() (err error) {
 	var allContexts, selectedContexts []string
 	if len(b.Options.Contexts) != 0 && !b.Options.UseContextSet {
@@ -614,14 +665,18 @@ func (b CSolutionBuilder) build() (err error) {
 		return err
 	}
 
-	needRebuild, err := b.needRebuild()
+	rebuildReasons, err := b.getRebuildReasons()
 	if err != nil {
 		log.Error(err)
 		return err
 	}
-	if needRebuild {
-		// Perform the clean operation
-		err := b.Clean()
+	b.explainRebuild(rebuildReasons)
+	if len(rebuildReasons) > 0 {
+		// Perform the clean operation, the rebuild conditions affect all contexts
+		cleanableContexts, err := b.getContextsToClean()
+		if err == nil {
+			err = b.clean(cleanableContexts, true)
+		}
 		if err != nil {
 			log.Error(err)
 			return err
@@ -634,13 +689,54 @@ func (b CSolutionBuilder) build() (err error) {
 		// build only cmake target when --target is specified
 		err = projBuilders[0].Build()
 	}
+	if err == nil && b.Options.UseCbuild2CMake {
+		// record the solution directory for a later relocation of the build tree
+		if err = b.recordSolutionDir(); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	if err == nil && b.Setup {
+		if err = b.postProcessSetup(selectedContexts); err != nil {
+			log.Error(err)
+		}
+	}
 	return err
 }
 
+// postProcessSetup processes the compilation databases and generates the IDE
+// configuration files of the selected contexts after the setup
+func (b CSolutionBuilder) postProcessSetup(selectedContexts []string) error {
+	if !b.Options.NoDatabase {
+		if err := b.postProcessDatabases(selectedContexts); err != nil {
+			return err
+		}
+	}
+	if b.Options.VSCode {
+		if err := b.generateCppProperties(selectedContexts); err != nil {
+			return err
+		}
+	}
+	if b.Options.DebugConfig == DebugConfigVSCode {
+		return b.generateLaunchConfigurations(selectedContexts)
+	}
+	return nil
+}
+
 func (b CSolutionBuilder) Build() (err error) {
 	env := utils.UpdateEnvVars(b.InstallConfigs.BinPath, b.InstallConfigs.EtcPath)
 	b.InstallConfigs.EtcPath = env.CompilerRoot
 
+	empty, err := b.resolveContextExpressions()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if empty {
+		utils.LogStdMsg("No context selected, nothing to build")
+		return nil
+	}
+
 	if !b.Options.SkipConvert || !b.buildFilesExist() {
 		// STEP 1: Install missing pack(s)
 		if err = b.InstallMissingPacks(); err != nil {
@@ -652,6 +748,9 @@ func (b CSolutionBuilder) Build() (err error) {
 		}
 		// STEP 2: Generate build file(s)
 		if err = b.generateBuildFiles(); err != nil {
+			if selectionErr := b.getSelectionError(); selectionErr != nil {
+				err = selectionErr
+			}
 			log.Error(err)
 			return err
 		}
@@ -661,6 +760,61 @@ func (b CSolutionBuilder) Build() (err error) {
 	return b.build()
 }
 
+// getSelectionError checks the selected contexts, target-set and toolchain against
+// the ones available in the solution, and returns an error suggesting the closest
+// matches for an unknown selection
+func (b CSolutionBuilder) getSelectionError() error {
+	builder := b
+	builder.Options.Contexts = nil
+	builder.Options.UseContextSet = false
+	builder.Options.TargetSet = ""
+	builder.Options.UseTargetSet = false
+	builder.Options.Toolchain = ""
+	builder.Options.SchemaChk = false
+
+	if len(b.Options.Contexts) > 0 {
+		if allContexts, err := builder.listContexts(true, true); err == nil {
+			if _, err := utils.ResolveContexts(allContexts, b.Options.Contexts); err != nil {
+				return err
+			}
+		}
+	}
+
+	if b.Options.UseTargetSet && b.Options.TargetSet != "" {
+		if targetSets, err := builder.listTargetSets(true); err == nil && len(targetSets) > 0 {
+			found := false
+			for _, targetSet := range targetSets {
+				// '<target-type>' selects the first set of the target-type
+				targetType, _, _ := strings.Cut(targetSet, "@")
+				if targetSet == b.Options.TargetSet || targetType == b.Options.TargetSet {
+					found = true
+					break
+				}
+			}
+			if !found {
+				err := errutils.New(errutils.ErrUnknownTargetSet, b.Options.TargetSet)
+				return utils.AddSuggestions(err, b.Options.TargetSet, targetSets)
+			}
+		}
+	}
+
+	if b.Options.Toolchain != "" {
+		if toolchains, err := builder.listToolchains(true); err == nil && len(toolchains) > 0 {
+			var names []string
+			for _, toolchain := range toolchains {
+				name, _, _ := strings.Cut(toolchain, "@")
+				names = append(names, name)
+			}
+			name, _, _ := strings.Cut(b.Options.Toolchain, "@")
+			if !slices.Contains(names, name) {
+				err := errutils.New(errutils.ErrUnknownToolchain, b.Options.Toolchain)
+				return utils.AddSuggestions(err, name, names)
+			}
+		}
+	}
+	return nil
+}
+
 func (b CSolutionBuilder) buildFilesExist() bool {
 	// Check cbuild-idx file
 	idxFile, err := b.getIdxFilePath()
@@ -668,7 +822,7 @@ func (b CSolutionBuilder) buildFilesExist() bool {
 		return false
 	}
 	// Check cbuild files
-	data, err := utils.ParseCbuildIndexFile(idxFile)
+	data, err := b.ParseCbuildIndexFile(idxFile)
 	if err != nil {
 		return false
 	}
@@ -681,90 +835,118 @@ func (b CSolutionBuilder) buildFilesExist() bool {
 	return true
 }
 
-func (b CSolutionBuilder) needRebuild() (bool, error) {
+// getRebuildReasons returns the reasons requiring a full rebuild of the solution
+func (b CSolutionBuilder) getRebuildReasons() (reasons []string, err error) {
 	// Rebuild was already requested by user
 	if b.Options.Rebuild || !b.Options.UseCbuild2CMake {
-		return false, nil
+		return nil, nil
 	}
 
 	// Check if the project is moved or renamed or tmp dir is changed
-	if b.isProjectMoved() {
-		return true, nil
+	if reason := b.getProjectMovedReason(); reason != "" {
+		return []string{reason}, nil
 	}
 
 	// Get cbuild-idx file path
 	idxFilePath, err := b.getIdxFilePath()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Read .cbuild-idx.yml and check if "rebuild" node exist
-	rebuild, err := b.hasRebuildNode(idxFilePath)
+	reasons, err = b.getRebuildNodeReasons(idxFilePath)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Check if CMSIS_COMPILER_ROOT is changed since last build
-	if b.isCompilerRootChanged() {
-		return true, nil
+	if reason := b.getCompilerRootChangedReason(); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	return reasons, nil
+}
+
+// explainRebuild reports the reasons of a full rebuild, in verbose mode
+// or when requested with '--explain'
+func (b CSolutionBuilder) explainRebuild(reasons []string) {
+	for _, reason := range reasons {
+		if b.Options.Explain {
+			utils.LogStdMsg("Rebuild reason: " + reason)
+		} else {
+			log.Info("rebuild triggered: " + reason)
+		}
+	}
+	if b.Options.Explain && len(reasons) == 0 {
+		if b.Options.Rebuild {
+			utils.LogStdMsg("Rebuild reason: requested with '--rebuild' option")
+		} else {
+			utils.LogStdMsg("No full rebuild required")
+		}
 	}
-	return rebuild, nil
 }
 
-func (b CSolutionBuilder) isProjectMoved() bool {
-	csolutionAbsPath, _ := filepath.Abs(b.InputFile)
-	rootPath := filepath.Dir(csolutionAbsPath)
-	intDirPath := filepath.Join(rootPath, "tmp")
+// getTmpDir returns the absolute path of the solution tmp directory. It falls back
+// to the default 'tmp' folder when the csolution file cannot be read.
+func (b CSolutionBuilder) getTmpDir() string {
+	tmpDir, err := utils.GetTmpDir(b.InputFile, b.Options.Output)
+	if err != nil {
+		tmpDir = filepath.Join(b.getOutputBaseDir(), "tmp")
+	}
+	tmpDir, _ = filepath.Abs(tmpDir)
+	return tmpDir
+}
+
+// getOutputBaseDir returns the base directory of the generated files, which is
+// the '--output' folder if given, otherwise the directory of the csolution file.
+// A relative '--output' folder is relative to the working directory, see utils.GetTmpDir.
+func (b CSolutionBuilder) getOutputBaseDir() string {
+	if b.Options.Output != "" {
+		return b.Options.Output
+	}
+	return filepath.Dir(b.InputFile)
+}
+
+// getProjectMovedReason compares the location of the tmp directory with the one
+// recorded in CMakeCache.txt and describes the difference
+func (b CSolutionBuilder) getProjectMovedReason() string {
+	intDirPath := b.getTmpDir()
 	cmakeCacheFile := filepath.Join(intDirPath, "CMakeCache.txt")
 
 	// check if input file exists
 	_, err := utils.FileExists(cmakeCacheFile)
 	if err != nil {
 		// File doesn't exist, rebuild not needed
-		return false
+		return ""
 	}
 
-	file, err := os.Open(cmakeCacheFile)
+	path, err := utils.GetCMakeCacheFileDir(cmakeCacheFile)
 	if err != nil {
-		return true
-	}
-	defer file.Close()
-
-	// Initialize a scanner to read file
-	scanner := bufio.NewScanner(file)
-	prefixStr := "CMAKE_CACHEFILE_DIR:INTERNAL="
-
-	// Search for prefixStr
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, prefixStr) {
-			path := strings.TrimPrefix(line, prefixStr)
-			equal, _ := utils.ComparePaths(path, intDirPath)
-			if equal {
-				return false // paths match, rebuild not needed
-			} else {
-				return true // paths do not match, rebuild needed
-			}
-		}
+		// entry was not found in the file, rebuild needed
+		return err.Error()
 	}
-
-	// prefixStr was not found in the file, rebuild needed
-	return true
+	equal, _ := utils.ComparePaths(path, intDirPath)
+	if equal {
+		return "" // paths match, rebuild not needed
+	}
+	// paths do not match, rebuild needed
+	return "CMAKE_CACHEFILE_DIR in '" + cmakeCacheFile + "' changed from '" + path + "' to '" + filepath.ToSlash(intDirPath) +
+		"', use 'cbuild relocate' to keep the build tree after moving the solution"
 }
 
-// hasRebuildNode checks if there is any rebuild required based on the given index file path.
-// It checks also if solution tmp directory is present but context tmp is absent
-// It returns true if a rebuild is needed, otherwise false.
-func (b CSolutionBuilder) hasRebuildNode(idxFilePath string) (bool, error) {
+// getRebuildNodeReasons describes the 'rebuild' nodes of the index file
+// and the missing context tmp directories of an existing solution tmp directory
+func (b CSolutionBuilder) getRebuildNodeReasons(idxFilePath string) (reasons []string, err error) {
 	// Read the cbuild-idx file
-	data, err := utils.ParseCbuildIndexFile(idxFilePath)
+	data, err := b.ParseCbuildIndexFile(idxFilePath)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
+	idxFileName := filepath.Base(idxFilePath)
+
 	// Check if the main build index requires a rebuild
 	if data.BuildIdx.Rebuild {
-		return true, nil
+		return []string{"'" + idxFileName + "' has 'rebuild: true'"}, nil
 	}
 
 	// tmp directory path
@@ -773,87 +955,208 @@ func (b CSolutionBuilder) hasRebuildNode(idxFilePath string) (bool, error) {
 
 	// Check if any of the contexts requires a rebuild
 	for _, cbuild := range data.BuildIdx.Cbuilds {
+		context := cbuild.Project + cbuild.Configuration
 		if cbuild.Rebuild {
-			return true, nil
+			reasons = append(reasons, "'"+idxFileName+"' has 'rebuild: true' for context '"+context+"'")
+			continue
 		}
 		if tmpDirExists {
-			contextTmpDirExists, _ := utils.FileExists(
-				filepath.Join(tmpDir, cbuild.Project+cbuild.Configuration))
+			contextTmpDir := filepath.Join(tmpDir, context)
+			contextTmpDirExists, _ := utils.FileExists(contextTmpDir)
 			if !contextTmpDirExists {
-				return true, nil
+				reasons = append(reasons, "context tmp directory '"+filepath.ToSlash(contextTmpDir)+"' is missing")
 			}
 		}
 	}
 
-	return false, nil
+	return reasons, nil
 }
 
 func (b CSolutionBuilder) Clean() (err error) {
+	empty, err := b.resolveContextExpressions()
+	if err != nil {
+		return err
+	}
+	if empty {
+		utils.LogStdMsg("No context selected, nothing to clean")
+		return nil
+	}
+
 	// Get list of cleanable contexts
-	cleanableContexts, err := b.getContextsToClean()
+	cleanableContexts, allContexts, err := b.selectContextsToClean()
 	if err != nil {
 		return err
 	}
 
-	outputDir := b.Options.Output
+	// Shared files are only cleaned together with all contexts
+	return b.clean(cleanableContexts, len(cleanableContexts) == len(allContexts))
+}
 
+// clean removes the intermediate and output files of the given contexts. The whole
+// intermediate directory including the shared CMake files is only removed with 'all'
+// set, otherwise only the context specific intermediate directories are cleaned.
+func (b CSolutionBuilder) clean(cleanableContexts []string, all bool) (err error) {
 	var tmpDir string
 	if !b.Options.UseCbuild2CMake {
 		// Use default path when --cbuildgen option is used
-		tmpDir = filepath.Join(filepath.Dir(b.InputFile), outputDir, "tmp")
+		tmpDir = filepath.Join(b.getOutputBaseDir(), "tmp")
+		// cbuildgen intermediate directories are not context specific
+		all = true
 	} else {
-		tmpDir, err = utils.GetTmpDir(b.InputFile, outputDir)
-		if err != nil {
+		if _, err = utils.FileExists(b.InputFile); err != nil {
 			return err
 		}
+		tmpDir = b.getTmpDir()
 	}
 
-	// Clean tmp dir, avoid to delete *.cbuild.yml and *.cbuild-run.yml files
-	if err := utils.DeleteAll(tmpDir, []string{"*.cbuild.yml", "*.cbuild-run.yml"}); err != nil {
-		if !b.Options.Clean {
-			log.Warn(err.Error())
-		}
+	// Avoid to delete *.cbuild.yml and *.cbuild-run.yml files
+	preservePatterns := append([]string{"*.cbuild.yml", "*.cbuild-run.yml"}, b.Options.Keep...)
+
+	operation := "Cleaning"
+	if b.Options.DryRun {
+		operation = "Would clean"
 	}
 
-	// Clean out dir
+	// Clean tmp dir
+	var freedSize int64
+	if all {
+		freedSize += b.cleanDir(tmpDir, preservePatterns)
+	}
+
+	// Clean context dirs
 	var seplen int
 	for index, context := range cleanableContexts {
 		progress := fmt.Sprintf("(%s/%d)", strconv.Itoa(index+1), len(cleanableContexts))
-		cleanMsg := progress + " Cleaning context: \"" + cleanableContexts[index] + "\""
+		cleanMsg := progress + " " + operation + " context: \"" + cleanableContexts[index] + "\""
 		if seplen == 0 {
 			seplen = len(cleanMsg)
 			utils.PrintSeparator("-", seplen)
 		}
 		utils.LogStdMsg(cleanMsg)
 
+		if !all {
+			// Keep the context directory itself, its absence would trigger a full rebuild
+			contextTmpDir := filepath.Join(tmpDir, utils.GetContextDirName(context))
+			freedSize += b.cleanDir(contextTmpDir, preservePatterns)
+		}
+
 		idxFile, err := b.getIdxFilePath()
 		if err == nil {
 			outDir, err := utils.GetOutDir(idxFile, context)
 			if err != nil {
 				log.Error("error cleaning '" + context + "'")
 			}
-			if err = utils.DeleteAll(outDir, []string{"*.cbuild.yml", "*.cbuild-run.yml"}); err != nil {
-				if !b.Options.Clean {
-					log.Warn(err.Error())
-				}
-			}
+			freedSize += b.cleanDir(outDir, preservePatterns)
 		}
 	}
 
+	if b.Options.DryRun {
+		utils.PrintSeparator("-", seplen)
+		utils.LogStdMsg("Disk space to be freed: " + utils.FormatSize(freedSize))
+		return nil
+	}
 	if b.Options.Clean {
 		utils.PrintSeparator("-", seplen)
+		utils.LogStdMsg("Freed disk space: " + utils.FormatSize(freedSize))
 	}
 	log.Info("clean finished successfully!")
 	return nil
 }
 
+// cleanDir deletes the directory content except the files matching the patterns
+// and returns the size of the deleted files. In dry-run mode the affected files
+// are only listed.
+func (b CSolutionBuilder) cleanDir(path string, preservePatterns []string) (freedSize int64) {
+	removed, preserved, err := utils.ListDeleteAll(path, preservePatterns)
+	if err != nil {
+		if !b.Options.Clean {
+			log.Warn(err.Error())
+		}
+		return 0
+	}
+	for _, entry := range removed {
+		freedSize += entry.Size
+	}
+
+	if b.Options.DryRun {
+		baseDir := filepath.Dir(b.InputFile)
+		for _, entry := range removed {
+			displayPath := b.displayPath(baseDir, entry.Path)
+			if entry.IsDir {
+				displayPath += "/"
+			}
+			utils.LogStdMsg("  remove:   " + displayPath)
+		}
+		for _, entry := range preserved {
+			utils.LogStdMsg("  preserve: " + b.displayPath(baseDir, entry.Path))
+		}
+		return freedSize
+	}
+
+	if err := utils.DeleteAll(path, preservePatterns); err != nil {
+		if !b.Options.Clean {
+			log.Warn(err.Error())
+		}
+	}
+	return freedSize
+}
+
+// displayPath returns the path relative to the solution directory where possible
+func (b CSolutionBuilder) displayPath(baseDir string, path string) string {
+	absBaseDir, _ := filepath.Abs(baseDir)
+	absPath, _ := filepath.Abs(path)
+	if relPath, err := filepath.Rel(absBaseDir, absPath); err == nil && !strings.HasPrefix(relPath, "..") {
+		return filepath.ToSlash(relPath)
+	}
+	return filepath.ToSlash(path)
+}
+
+// resolveContextExpressions replaces the context filters by the names of the selected
+// contexts when exclusions or regular expressions are used, which are not supported by
+// csolution. It returns true if no context is left with '--allow-empty'.
+func (b *CSolutionBuilder) resolveContextExpressions() (empty bool, err error) {
+	if len(b.Options.Contexts) == 0 && len(b.Options.ExcludeContexts) == 0 {
+		return false, nil
+	}
+	if !utils.HasContextExpressions(b.Options.Contexts) && len(b.Options.ExcludeContexts) == 0 && !b.Options.AllowEmpty {
+		return false, nil
+	}
+
+	// Retrieve all available contexts
+	builder := *b
+	builder.Options.Contexts = nil
+	builder.Options.SchemaChk = false
+	allContexts, err := builder.listContexts(true, true)
+	if err != nil {
+		return false, err
+	}
+
+	contexts, err := utils.SelectContexts(allContexts, b.Options.Contexts, utils.ContextSelection{
+		Exclude:    b.Options.ExcludeContexts,
+		AllowEmpty: b.Options.AllowEmpty,
+	})
+	if err != nil {
+		return false, err
+	}
+	log.Debug("selected contexts: " + strings.Join(contexts, " "))
+	b.Options.Contexts = contexts
+	b.Options.ExcludeContexts = nil
+	return len(contexts) == 0, nil
+}
+
 func (b *CSolutionBuilder) getContextsToClean() (contexts []string, err error) {
+	contexts, _, err = b.selectContextsToClean()
+	return contexts, err
+}
+
+// selectContextsToClean returns the contexts selected for cleaning together with all available contexts
+func (b *CSolutionBuilder) selectContextsToClean() (contexts []string, allContexts []string, err error) {
 	// Retrieve all available contexts
 	builder := b
 	builder.Options.SchemaChk = false
-	allContexts, err := builder.listContexts(true, true)
+	allContexts, err = builder.listContexts(true, true)
 	if err != nil {
-		return []string{}, err
+		return []string{}, nil, err
 	}
 
 	hasContextOption := (len(b.Options.Contexts) > 0 && !b.Options.UseContextSet)
@@ -861,7 +1164,7 @@ func (b *CSolutionBuilder) getContextsToClean() (contexts []string, err error) {
 
 	if hasTargetSetOption && (len(b.Options.Contexts) > 0 || b.Options.UseContextSet) {
 		err := errutils.New(errutils.ErrInvalidTargetSetUsage)
-		return []string{}, err
+		return []string{}, allContexts, err
 	}
 
 	if hasContextOption || hasTargetSetOption {
@@ -878,30 +1181,33 @@ func (b *CSolutionBuilder) getContextsToClean() (contexts []string, err error) {
 		// Resolve contexts if inputs are available
 		contexts, err = utils.ResolveContexts(allContexts, contextInputs)
 		if err != nil {
-			return []string{}, err
+			return []string{}, allContexts, err
 		}
-		return contexts, nil
+		return contexts, allContexts, nil
 	}
 
 	// Handle context selection from a cbuild set file
 	if b.Options.UseContextSet {
 		filePath := b.getCbuildSetFilePath()
 		if exists, err := utils.FileExists(filePath); err != nil || !exists {
-			return []string{}, err
+			return []string{}, allContexts, err
 		}
 
 		contexts, err = b.getSelectedContexts(filePath)
 		if err != nil {
-			return []string{}, err
+			return []string{}, allContexts, err
 		}
-		return contexts, nil
+		return contexts, allContexts, nil
 	}
 
 	// Default to using all available contexts
-	return allContexts, nil
+	return allContexts, allContexts, nil
 }
 
 func (b CSolutionBuilder) ListTargetSets() error {
+	if b.Options.Format == FormatJSON {
+		return b.listTargetSetsJSON()
+	}
 	_, err := b.listTargetSets(false)
 	return err
 }
@@ -920,19 +1226,15 @@ func (b CSolutionBuilder) listTargetSets(quiet bool) (targetSets []string, err e
 	return targetSets, nil
 }
 
-// isCompilerRootChanged compares the CMSIS_COMPILER_ROOT in roots.cmake with the given etcDirPath
-// Returns true if they differ, false if they match or if roots.cmake is missing or invalid
-func (b CSolutionBuilder) isCompilerRootChanged() bool {
-	tmpDir, err := utils.GetTmpDir(b.InputFile, b.Options.Output)
-	if err != nil {
-		return false
-	}
-
+// getCompilerRootChangedReason describes the change of CMSIS_COMPILER_ROOT since the last build,
+// it is empty if roots.cmake matches the etc directory or if roots.cmake is missing or invalid
+func (b CSolutionBuilder) getCompilerRootChangedReason() string {
+	tmpDir := b.getTmpDir()
 	rootsCMakePath := filepath.Join(tmpDir, "roots.cmake")
 	rootsCMakePath = filepath.Clean(rootsCMakePath)
 	exists, err := utils.FileExists(rootsCMakePath)
 	if err != nil {
-		return false
+		return ""
 	}
 
 	if exists {
@@ -941,10 +1243,10 @@ func (b CSolutionBuilder) isCompilerRootChanged() bool {
 			absCompilerRoot, _ := filepath.Abs(compilerRoot)
 			absEtcPath, _ := filepath.Abs(b.InstallConfigs.EtcPath)
 			if filepath.Clean(absCompilerRoot) != filepath.Clean(absEtcPath) {
-				return true
+				return "CMSIS_COMPILER_ROOT changed from '" + filepath.ToSlash(absCompilerRoot) + "' to '" + filepath.ToSlash(absEtcPath) + "'"
 			}
 		}
 	}
 
-	return false
+	return ""
 }
@@ -7,14 +7,17 @@
 package csolution
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/cbuildidx"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -541,35 +544,36 @@ func TestGetCbuildSetFilePath(t *testing.T) {
 	})
 }
 
-func TestHasRebuildNode(t *testing.T) {
+func TestGetRebuildNodeReasons(t *testing.T) {
 	assert := assert.New(t)
 
 	b := CSolutionBuilder{}
 
 	t.Run("test file not found", func(t *testing.T) {
-		rebuild, err := b.hasRebuildNode("non_existing_file")
+		reasons, err := b.getRebuildNodeReasons("non_existing_file")
 		assert.Error(err)
-		assert.False(rebuild)
+		assert.Empty(reasons)
 	})
 
 	t.Run("test no contexts to rebuild", func(t *testing.T) {
 		testIdxFile := filepath.Join(testRoot, testDir, "Test.cbuild-idx.yml")
 
-		rebuild, err := b.hasRebuildNode(testIdxFile)
+		reasons, err := b.getRebuildNodeReasons(testIdxFile)
 		assert.Nil(err)
-		assert.False(rebuild)
+		assert.Empty(reasons)
 	})
 
 	t.Run("test contexts to rebuild found", func(t *testing.T) {
 		testIdxFile := filepath.Join(testRoot, testDir, "Rebuild.cbuild-idx.yml")
 
-		rebuild, err := b.hasRebuildNode(testIdxFile)
+		reasons, err := b.getRebuildNodeReasons(testIdxFile)
 		assert.Nil(err)
-		assert.True(rebuild)
+		assert.Contains(reasons, "'Rebuild.cbuild-idx.yml' has 'rebuild: true' for context 'HelloWorld_cm0plus.Debug+FRDM-K32L3A6'")
+		assert.Contains(reasons, "'Rebuild.cbuild-idx.yml' has 'rebuild: true' for context 'HelloWorld_cm4.Release+FRDM-K32L3A6'")
 	})
 }
 
-func TestIsProjectMoved(t *testing.T) {
+func TestGetProjectMovedReason(t *testing.T) {
 	assert := assert.New(t)
 	tmpDir := filepath.Join(testRoot, testDir, "tmp")
 	_ = os.RemoveAll(tmpDir)
@@ -589,7 +593,7 @@ func TestIsProjectMoved(t *testing.T) {
 	}
 
 	t.Run("test cache file not found", func(t *testing.T) {
-		assert.False(b.isProjectMoved())
+		assert.Empty(b.getProjectMovedReason())
 	})
 
 	t.Run("test project not moved", func(t *testing.T) {
@@ -597,7 +601,7 @@ func TestIsProjectMoved(t *testing.T) {
 		writeTestCMakeCache(tmpDir, content)
 		defer os.RemoveAll(tmpDir)
 
-		assert.False(b.isProjectMoved())
+		assert.Empty(b.getProjectMovedReason())
 	})
 
 	t.Run("test project moved", func(t *testing.T) {
@@ -605,11 +609,20 @@ func TestIsProjectMoved(t *testing.T) {
 		writeTestCMakeCache(tmpDir, content)
 		defer os.RemoveAll(tmpDir)
 
-		assert.True(b.isProjectMoved())
+		reason := b.getProjectMovedReason()
+		assert.Contains(reason, "CMAKE_CACHEFILE_DIR in '")
+		assert.Contains(reason, "changed from '/home/test/tmp' to '")
+	})
+
+	t.Run("test cache entry missing", func(t *testing.T) {
+		writeTestCMakeCache(tmpDir, "CMAKE_HOME_DIRECTORY:INTERNAL=/home/test")
+		defer os.RemoveAll(tmpDir)
+
+		assert.Contains(b.getProjectMovedReason(), "CMAKE_CACHEFILE_DIR not found in '")
 	})
 }
 
-func TestNeedRebuild(t *testing.T) {
+func TestGetRebuildReasons(t *testing.T) {
 	assert := assert.New(t)
 	tmpDir := filepath.Join(testRoot, testDir, "tmp")
 	_ = os.RemoveAll(tmpDir)
@@ -623,25 +636,25 @@ func TestNeedRebuild(t *testing.T) {
 
 	t.Run("rebuild needed when user specifies -r", func(t *testing.T) {
 		b.Options.Rebuild = true
-		rebuild, err := b.needRebuild()
+		reasons, err := b.getRebuildReasons()
 		assert.Nil(err)
-		assert.False(rebuild)
+		assert.Empty(reasons)
 	})
 
 	t.Run("check rebuild only when --cbuild2cmake", func(t *testing.T) {
 		b.Options.Rebuild = false
 		b.Options.UseCbuild2CMake = false
-		rebuild, err := b.needRebuild()
+		reasons, err := b.getRebuildReasons()
 		assert.Nil(err)
-		assert.False(rebuild)
+		assert.Empty(reasons)
 	})
 
 	t.Run("check rebuild needed on new project", func(t *testing.T) {
 		b.Options.Rebuild = false
 		b.Options.UseCbuild2CMake = true
-		rebuild, err := b.needRebuild()
+		reasons, err := b.getRebuildReasons()
 		assert.Nil(err)
-		assert.False(rebuild)
+		assert.Empty(reasons)
 	})
 
 	t.Run("check rebuild needed when project moved", func(t *testing.T) {
@@ -653,9 +666,9 @@ func TestNeedRebuild(t *testing.T) {
 		cmakeCacheFile := filepath.Join(tmpDir, "CMakeCache.txt")
 		_ = os.WriteFile(cmakeCacheFile, []byte(content), 0600)
 
-		rebuild, err := b.needRebuild()
+		reasons, err := b.getRebuildReasons()
 		assert.Nil(err)
-		assert.True(rebuild)
+		assert.NotEmpty(reasons)
 
 		_ = os.RemoveAll(tmpDir)
 	})
@@ -669,9 +682,9 @@ func TestNeedRebuild(t *testing.T) {
 		cmakeCacheFile := filepath.Join(tmpDir, "CMakeCache.txt")
 		_ = os.WriteFile(cmakeCacheFile, []byte(content), 0600)
 
-		rebuild, err := b.needRebuild()
+		reasons, err := b.getRebuildReasons()
 		assert.Nil(err)
-		assert.False(rebuild)
+		assert.Empty(reasons)
 
 		_ = os.RemoveAll(tmpDir)
 	})
@@ -686,14 +699,64 @@ func TestNeedRebuild(t *testing.T) {
 		cmakeCacheFile := filepath.Join(tmpDir, "CMakeCache.txt")
 		_ = os.WriteFile(cmakeCacheFile, []byte(content), 0600)
 
-		rebuild, err := b.needRebuild()
+		reasons, err := b.getRebuildReasons()
 		assert.Nil(err)
-		assert.True(rebuild)
+		assert.NotEmpty(reasons)
+		for _, reason := range reasons {
+			assert.Contains(reason, "context tmp directory '")
+			assert.Contains(reason, "' is missing")
+		}
 
 		_ = os.RemoveAll(tmpDir)
 	})
 }
 
+func TestExplainRebuild(t *testing.T) {
+	assert := assert.New(t)
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: RunnerMock{},
+		},
+	}
+
+	captureOutput := func(reasons []string) string {
+		var logBuffer bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&logBuffer)
+		b.explainRebuild(reasons)
+		return logBuffer.String()
+	}
+
+	t.Run("explain reasons", func(t *testing.T) {
+		b.Options.Explain = true
+		out := captureOutput([]string{"first reason", "second reason"})
+		assert.Equal("Rebuild reason: first reason\nRebuild reason: second reason\n", out)
+	})
+
+	t.Run("explain no rebuild required", func(t *testing.T) {
+		b.Options.Explain = true
+		out := captureOutput(nil)
+		assert.Equal("No full rebuild required\n", out)
+	})
+
+	t.Run("explain rebuild requested by user", func(t *testing.T) {
+		b.Options.Explain = true
+		b.Options.Rebuild = true
+		out := captureOutput(nil)
+		assert.Equal("Rebuild reason: requested with '--rebuild' option\n", out)
+	})
+
+	t.Run("verbose output without --explain", func(t *testing.T) {
+		b.Options.Explain = false
+		b.Options.Rebuild = false
+		out := captureOutput([]string{"first reason"})
+		assert.Contains(out, "rebuild triggered: first reason")
+		assert.NotContains(out, "Rebuild reason:")
+	})
+}
+
 func TestGetContextsToClean(t *testing.T) {
 	assert := assert.New(t)
 	b := CSolutionBuilder{
@@ -748,7 +811,7 @@ func TestGetContextsToClean(t *testing.T) {
 	})
 }
 
-func TestIsCompilerRootChanged(t *testing.T) {
+func TestGetCompilerRootChangedReason(t *testing.T) {
 	assert := assert.New(t)
 
 	baseDir := utils.NormalizePath(filepath.Join(testRoot, testDir, "TestSolution"))
@@ -788,7 +851,7 @@ func TestIsCompilerRootChanged(t *testing.T) {
 		content := `set(CMSIS_COMPILER_ROOT \"` + baseDir + `\" CACHE PATH \"CMSIS compiler root\")`
 		writeRoots(content)
 		b := getBuilder(baseDir)
-		assert.False(b.isCompilerRootChanged(), "expected false, got true")
+		assert.Empty(b.getCompilerRootChangedReason())
 	})
 
 	// Case 2: roots.cmake exists, CMSIS_COMPILER_ROOT differs from etcPath
@@ -796,14 +859,14 @@ func TestIsCompilerRootChanged(t *testing.T) {
 		content := `set(CMSIS_COMPILER_ROOT \"/some/other/path\" CACHE PATH \"CMSIS compiler root\")`
 		writeRoots(content)
 		b := getBuilder(baseDir)
-		assert.True(b.isCompilerRootChanged(), "expected true, got false")
+		assert.Contains(b.getCompilerRootChangedReason(), "CMSIS_COMPILER_ROOT changed from '/some/other/path' to '"+filepath.ToSlash(baseDir)+"'")
 	})
 
 	// Case 3: roots.cmake missing
 	t.Run("roots.cmake missing", func(t *testing.T) {
 		os.RemoveAll(filepath.Join(baseDir, "tmpdir"))
 		b := getBuilder(baseDir)
-		assert.False(b.isCompilerRootChanged(), "expected false, got true")
+		assert.Empty(b.getCompilerRootChangedReason())
 	})
 
 	// Case 4: roots.cmake exists, but CMSIS_COMPILER_ROOT missing
@@ -811,7 +874,7 @@ func TestIsCompilerRootChanged(t *testing.T) {
 		content := `set(SOME_OTHER_VAR \"/foo/bar\")`
 		writeRoots(content)
 		b := getBuilder(baseDir)
-		assert.False(b.isCompilerRootChanged(), "expected false, got true")
+		assert.Empty(b.getCompilerRootChangedReason())
 	})
 
 	// Case 5: roots.cmake exists, but CMSIS_COMPILER_ROOT malformed
@@ -819,7 +882,7 @@ func TestIsCompilerRootChanged(t *testing.T) {
 		content := `set(CMSIS_COMPILER_ROOT /no/quotes)`
 		writeRoots(content)
 		b := getBuilder(baseDir)
-		assert.False(b.isCompilerRootChanged(), "expected false, got true")
+		assert.Empty(b.getCompilerRootChangedReason())
 	})
 
 }
@@ -839,7 +902,7 @@ func TestCleanTmpOut(t *testing.T) {
 	}
 	t.Run("test clean when 'out' directory is inside 'tmp' directory", func(t *testing.T) {
 		b.Options.UseCbuild2CMake = true
-		b.Options.Contexts = []string{"test.Debug+CM0"}
+		b.Options.Contexts = []string{"test.Debug+CM0", "test.Release+CM0"}
 		tmpDir := filepath.Join(testRoot, testDir, "TestSolution/tmpdir")
 		outDir := filepath.Join(tmpDir, "test")
 		_ = os.MkdirAll(outDir, os.ModePerm)
@@ -855,6 +918,75 @@ func TestCleanTmpOut(t *testing.T) {
 
 		_ = os.RemoveAll(tmpDir)
 	})
+
+	t.Run("test clean of a single context", func(t *testing.T) {
+		b.Options.UseCbuild2CMake = true
+		b.Options.Contexts = []string{"test.Debug+CM0"}
+		tmpDir := filepath.Join(testRoot, testDir, "TestSolution/tmpdir")
+		contextFile := filepath.Join(tmpDir, "test.Debug+CM0", "CMakeCache.txt")
+		otherContextFile := filepath.Join(tmpDir, "test.Release+CM0", "CMakeCache.txt")
+		sharedFile := filepath.Join(tmpDir, "CMakeCache.txt")
+		for _, file := range []string{contextFile, otherContextFile, sharedFile} {
+			_ = os.MkdirAll(filepath.Dir(file), os.ModePerm)
+			_ = os.WriteFile(file, []byte("dummy"), 0600)
+		}
+
+		err := b.Clean()
+		assert.Nil(err)
+		assert.NoFileExists(contextFile)
+		assert.DirExists(filepath.Dir(contextFile))
+		assert.FileExists(otherContextFile)
+		assert.FileExists(sharedFile)
+
+		_ = os.RemoveAll(tmpDir)
+	})
+}
+
+func TestCleanDryRunAndKeep(t *testing.T) {
+	assert := assert.New(t)
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml"),
+			Options: builder.Options{
+				UseCbuild2CMake: true,
+				Contexts:        []string{"test.Debug+CM0"},
+			},
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+		},
+	}
+	tmpDir := filepath.Join(testRoot, testDir, "TestSolution/tmpdir")
+	cbuildFile := filepath.Join(tmpDir, "test.Debug+CM0", "test.Debug+CM0.cbuild.yml")
+	mapFile := filepath.Join(tmpDir, "test.Debug+CM0", "test.map")
+	objFile := filepath.Join(tmpDir, "test.Debug+CM0", "main.o")
+	_ = os.MkdirAll(filepath.Dir(cbuildFile), os.ModePerm)
+	_ = os.WriteFile(cbuildFile, []byte("dummy"), 0600)
+	_ = os.WriteFile(mapFile, []byte("dummy"), 0600)
+	_ = os.WriteFile(objFile, []byte("dummy"), 0600)
+	defer os.RemoveAll(tmpDir)
+
+	t.Run("dry run does not delete files", func(t *testing.T) {
+		b.Options.DryRun = true
+		err := b.Clean()
+		assert.Nil(err)
+		assert.FileExists(cbuildFile)
+		assert.FileExists(mapFile)
+		assert.FileExists(objFile)
+	})
+
+	t.Run("keep additional files", func(t *testing.T) {
+		b.Options.DryRun = false
+		b.Options.Keep = []string{"*.map"}
+		err := b.Clean()
+		assert.Nil(err)
+		assert.FileExists(cbuildFile)
+		assert.FileExists(mapFile)
+		assert.NoFileExists(objFile)
+	})
 }
 
 func TestRunCSolutionQuietMode(t *testing.T) {
@@ -886,3 +1018,134 @@ func TestRunCSolutionQuietMode(t *testing.T) {
 		assert.Contains(runnerCapture.capturedArgs, "some-file.yml")
 	})
 }
+
+func TestGetProjsBuildersProgress(t *testing.T) {
+	assert := assert.New(t)
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(testRoot, testDir, "Test.csolution.yml"),
+			Options: builder.Options{
+				UseCbuild2CMake: true,
+				LogFile:         "build.log",
+			},
+		},
+	}
+	contexts := []string{"HelloWorld_cm0plus.Debug+FRDM-K32L3A6"}
+
+	t.Run("default runner", func(t *testing.T) {
+		projBuilders, err := b.getProjsBuilders(contexts)
+		assert.Nil(err)
+		assert.Len(projBuilders, 1)
+		assert.Equal(RunnerMock{}, projBuilders[0].(cbuildidx.CbuildIdxBuilder).Runner)
+	})
+
+	t.Run("progress runner", func(t *testing.T) {
+		b.Options.Progress = true
+		projBuilders, err := b.getProjsBuilders(contexts)
+		assert.Nil(err)
+		assert.Equal(utils.ProgressRunner{Context: contexts[0], LogFile: "build.log"}, projBuilders[0].(cbuildidx.CbuildIdxBuilder).Runner)
+	})
+
+	t.Run("progress disabled in verbose mode", func(t *testing.T) {
+		b.Options.Verbose = true
+		projBuilders, err := b.getProjsBuilders(contexts)
+		assert.Nil(err)
+		assert.Equal(RunnerMock{}, projBuilders[0].(cbuildidx.CbuildIdxBuilder).Runner)
+	})
+}
+
+func TestResolveContextExpressions(t *testing.T) {
+	assert := assert.New(t)
+
+	newBuilder := func(options builder.Options) CSolutionBuilder {
+		return CSolutionBuilder{
+			BuilderParams: builder.BuilderParams{
+				Runner:    RunnerMock{},
+				InputFile: filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml"),
+				Options:   options,
+				InstallConfigs: utils.Configurations{
+					BinPath: configs.BinPath,
+					BinExtn: configs.BinExtn,
+					EtcPath: configs.EtcPath,
+				},
+			},
+		}
+	}
+
+	t.Run("plain filters are passed to csolution", func(t *testing.T) {
+		b := newBuilder(builder.Options{Contexts: []string{"test.Debug"}})
+		empty, err := b.resolveContextExpressions()
+		assert.Nil(err)
+		assert.False(empty)
+		assert.Equal([]string{"test.Debug"}, b.Options.Contexts)
+	})
+
+	t.Run("exclusion", func(t *testing.T) {
+		b := newBuilder(builder.Options{Contexts: []string{"!.Debug"}})
+		empty, err := b.resolveContextExpressions()
+		assert.Nil(err)
+		assert.False(empty)
+		assert.Equal([]string{"test.Release+CM0"}, b.Options.Contexts)
+	})
+
+	t.Run("exclude context option", func(t *testing.T) {
+		b := newBuilder(builder.Options{ExcludeContexts: []string{"re:.*Release.*"}})
+		empty, err := b.resolveContextExpressions()
+		assert.Nil(err)
+		assert.False(empty)
+		assert.Equal([]string{"test.Debug+CM0"}, b.Options.Contexts)
+		assert.Empty(b.Options.ExcludeContexts)
+	})
+
+	t.Run("no matching context", func(t *testing.T) {
+		b := newBuilder(builder.Options{Contexts: []string{"!test"}})
+		_, err := b.resolveContextExpressions()
+		assert.Error(err)
+	})
+
+	t.Run("allow empty selection", func(t *testing.T) {
+		b := newBuilder(builder.Options{Contexts: []string{"unknown"}, AllowEmpty: true})
+		empty, err := b.resolveContextExpressions()
+		assert.Nil(err)
+		assert.True(empty)
+
+		b = newBuilder(builder.Options{Contexts: []string{"!test"}, AllowEmpty: true})
+		assert.Nil(b.Build())
+		assert.Nil(b.Clean())
+	})
+}
+
+func TestGetSelectionError(t *testing.T) {
+	assert := assert.New(t)
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml"),
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+		},
+	}
+
+	t.Run("valid selection", func(t *testing.T) {
+		b.Options.Contexts = []string{"test.Debug"}
+		b.Options.Toolchain = "AC6@>=6.18.0"
+		assert.Nil(b.getSelectionError())
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		b.Options.Contexts = []string{"tset.Debug"}
+		b.Options.Toolchain = ""
+		assert.EqualError(b.getSelectionError(), "no valid context found for 'tset.Debug'. Did you mean 'test.Debug'?")
+	})
+
+	t.Run("unknown toolchain", func(t *testing.T) {
+		b.Options.Contexts = nil
+		b.Options.Toolchain = "GCCC@11.2.1"
+		assert.EqualError(b.getSelectionError(), "unknown toolchain 'GCCC@11.2.1'. Did you mean 'GCC'?")
+	})
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CoberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        string             `xml:"line-rate,attr"`
+	BranchRate      string             `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      string             `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []CoberturaPackage `xml:"packages>package"`
+}
+
+type CoberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   string           `xml:"line-rate,attr"`
+	BranchRate string           `xml:"branch-rate,attr"`
+	Complexity string           `xml:"complexity,attr"`
+	Classes    []CoberturaClass `xml:"classes>class"`
+}
+
+type CoberturaClass struct {
+	Name       string            `xml:"name,attr"`
+	Filename   string            `xml:"filename,attr"`
+	LineRate   string            `xml:"line-rate,attr"`
+	BranchRate string            `xml:"branch-rate,attr"`
+	Complexity string            `xml:"complexity,attr"`
+	Methods    []CoberturaMethod `xml:"methods>method"`
+	Lines      []CoberturaLine   `xml:"lines>line"`
+}
+
+type CoberturaMethod struct {
+	Name       string          `xml:"name,attr"`
+	Signature  string          `xml:"signature,attr"`
+	LineRate   string          `xml:"line-rate,attr"`
+	BranchRate string          `xml:"branch-rate,attr"`
+	Lines      []CoberturaLine `xml:"lines>line"`
+}
+
+type CoberturaLine struct {
+	Number int   `xml:"number,attr"`
+	Hits   int64 `xml:"hits,attr"`
+	Branch bool  `xml:"branch,attr"`
+}
+
+func formatRate(found int, hit int) string {
+	return strconv.FormatFloat(getLineRate(found, hit), 'f', 4, 64)
+}
+
+// getCoberturaFilename returns the file path relative to the source directory
+// if the file is located in it
+func getCoberturaFilename(file string, sourceDir string) string {
+	if rel, err := filepath.Rel(sourceDir, filepath.FromSlash(file)); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return file
+}
+
+// getCoberturaReport converts the coverage into a Cobertura report with a
+// package per source directory and a class per source file
+func getCoberturaReport(coverage Coverage, sourceDir string) CoberturaCoverage {
+	found, hit := coverage.getLineCounts()
+	report := CoberturaCoverage{
+		LineRate:     formatRate(found, hit),
+		BranchRate:   "0",
+		LinesCovered: hit,
+		LinesValid:   found,
+		Complexity:   "0",
+		Version:      "cbuild",
+		Timestamp:    time.Now().Unix(),
+		Sources:      []string{filepath.ToSlash(sourceDir)},
+	}
+
+	packages := make(map[string]*CoberturaPackage)
+	packageCounts := make(map[string][2]int)
+	for _, fileCoverage := range coverage.getSortedFiles() {
+		filename := getCoberturaFilename(fileCoverage.File, sourceDir)
+		dir := path.Dir(filename)
+		pkg, ok := packages[dir]
+		if !ok {
+			pkg = &CoberturaPackage{Name: strings.ReplaceAll(dir, "/", "."), BranchRate: "0", Complexity: "0"}
+			packages[dir] = pkg
+		}
+		fileFound, fileHit := fileCoverage.getLineCounts()
+		counts := packageCounts[dir]
+		packageCounts[dir] = [2]int{counts[0] + fileFound, counts[1] + fileHit}
+
+		class := CoberturaClass{
+			Name:       strings.TrimSuffix(path.Base(filename), path.Ext(filename)),
+			Filename:   filename,
+			LineRate:   formatRate(fileFound, fileHit),
+			BranchRate: "0",
+			Complexity: "0",
+		}
+		for _, function := range fileCoverage.getSortedFunctions() {
+			rate := "0.0000"
+			if function.Count > 0 {
+				rate = "1.0000"
+			}
+			class.Methods = append(class.Methods, CoberturaMethod{
+				Name:       function.Name,
+				LineRate:   rate,
+				BranchRate: "0",
+				Lines:      []CoberturaLine{{Number: function.Line, Hits: function.Count}},
+			})
+		}
+		for _, line := range fileCoverage.getSortedLines() {
+			class.Lines = append(class.Lines, CoberturaLine{Number: line, Hits: fileCoverage.Lines[line]})
+		}
+		pkg.Classes = append(pkg.Classes, class)
+	}
+
+	dirs := make([]string, 0, len(packages))
+	for dir := range packages {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		counts := packageCounts[dir]
+		packages[dir].LineRate = formatRate(counts[0], counts[1])
+		report.Packages = append(report.Packages, *packages[dir])
+	}
+	return report
+}
+
+// writeCoberturaReport writes the coverage in the Cobertura XML format
+func writeCoberturaReport(file string, coverage Coverage, sourceDir string) error {
+	data, err := xml.MarshalIndent(getCoberturaReport(coverage, sourceDir), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	doctype := "<!DOCTYPE coverage SYSTEM \"http://cobertura.sourceforge.net/xml/coverage-04.dtd\">\n"
+	return os.WriteFile(file, []byte(xml.Header+doctype+fmt.Sprintf("%s\n", data)), 0600)
+}
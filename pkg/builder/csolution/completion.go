@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+const (
+	CompleteContexts   = "contexts"
+	CompleteTargetSets = "target-sets"
+	CompleteToolchains = "toolchains"
+)
+
+// CompletionCacheTTL is the time the completion values are reused without
+// running csolution again
+var CompletionCacheTTL = 30 * time.Second
+
+// getCompletionCacheFile returns the cache file of the completion values of a
+// solution, it is empty when no cache directory is available
+func (b CSolutionBuilder) getCompletionCacheFile(kind string) string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	key := kind
+	if b.InputFile != "" {
+		inputFile, _ := filepath.Abs(b.InputFile)
+		key += "\n" + inputFile
+	}
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(cacheDir, "cbuild", "completion", hex.EncodeToString(hash[:8])+".json")
+}
+
+// readCompletionCache returns the cached values unless they expired or the
+// solution changed since they were cached
+func (b CSolutionBuilder) readCompletionCache(cacheFile string) (values []string, ok bool) {
+	cacheInfo, err := os.Stat(cacheFile)
+	if err != nil || time.Since(cacheInfo.ModTime()) > CompletionCacheTTL {
+		return nil, false
+	}
+	if b.InputFile != "" {
+		if inputInfo, err := os.Stat(b.InputFile); err != nil || inputInfo.ModTime().After(cacheInfo.ModTime()) {
+			return nil, false
+		}
+	}
+	data, err := os.ReadFile(cacheFile)
+	if err != nil || json.Unmarshal(data, &values) != nil {
+		return nil, false
+	}
+	return values, true
+}
+
+func writeCompletionCache(cacheFile string, values []string) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(cacheFile, data, 0600)
+}
+
+// GetCompletionValues returns the contexts, target-sets or toolchains of the
+// solution for the shell completion. The values are cached briefly to keep the
+// completion responsive.
+func (b CSolutionBuilder) GetCompletionValues(kind string) (values []string, err error) {
+	cacheFile := b.getCompletionCacheFile(kind)
+	if cacheFile != "" {
+		if values, ok := b.readCompletionCache(cacheFile); ok {
+			return values, nil
+		}
+	}
+
+	switch kind {
+	case CompleteContexts:
+		values, err = b.listContexts(true, false)
+	case CompleteTargetSets:
+		values, err = b.listTargetSets(true)
+	case CompleteToolchains:
+		var toolchains []string
+		toolchains, err = b.listToolchains(true)
+		// toolchains are selected by name or by name and version
+		for _, toolchain := range toolchains {
+			name, _, _ := strings.Cut(toolchain, "@")
+			values = utils.AppendUnique(values, name)
+		}
+		values = append(values, toolchains...)
+	default:
+		err = errutils.New(errutils.ErrInvalidInputArg, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheFile != "" {
+		writeCompletionCache(cacheFile, values)
+	}
+	return values, nil
+}
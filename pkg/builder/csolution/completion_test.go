@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type CountingRunnerMock struct {
+	RunnerMock
+	calls *int
+}
+
+func (r CountingRunnerMock) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	*r.calls++
+	return r.RunnerMock.ExecuteCommand(program, quiet, args...)
+}
+
+func TestGetCompletionValues(t *testing.T) {
+	assert := assert.New(t)
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", cacheDir)
+	t.Setenv("LocalAppData", cacheDir)
+
+	var calls int
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    CountingRunnerMock{calls: &calls},
+			InputFile: filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml"),
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+		},
+	}
+
+	t.Run("contexts", func(t *testing.T) {
+		values, err := b.GetCompletionValues(CompleteContexts)
+		assert.Nil(err)
+		assert.Equal([]string{"test.Debug+CM0", "test.Release+CM0"}, values)
+		assert.Equal(1, calls)
+		assert.FileExists(b.getCompletionCacheFile(CompleteContexts))
+	})
+
+	t.Run("cached contexts", func(t *testing.T) {
+		values, err := b.GetCompletionValues(CompleteContexts)
+		assert.Nil(err)
+		assert.Equal([]string{"test.Debug+CM0", "test.Release+CM0"}, values)
+		assert.Equal(1, calls)
+	})
+
+	t.Run("expired cache", func(t *testing.T) {
+		cacheFile := b.getCompletionCacheFile(CompleteContexts)
+		expired := time.Now().Add(-2 * CompletionCacheTTL)
+		assert.Nil(os.Chtimes(cacheFile, expired, expired))
+		_, err := b.GetCompletionValues(CompleteContexts)
+		assert.Nil(err)
+		assert.Equal(2, calls)
+	})
+
+	t.Run("toolchains", func(t *testing.T) {
+		values, err := b.GetCompletionValues(CompleteToolchains)
+		assert.Nil(err)
+		assert.Equal([]string{"AC5", "AC6", "GCC", "IAR", "AC5@5.6.7", "AC6@6.18.0", "GCC@11.2.1", "IAR@8.50.6"}, values)
+	})
+
+	t.Run("cache per solution", func(t *testing.T) {
+		other := b
+		other.InputFile = filepath.Join(testRoot, testDir, "TestSolution/other.csolution.yml")
+		assert.NotEqual(b.getCompletionCacheFile(CompleteContexts), other.getCompletionCacheFile(CompleteContexts))
+		assert.NotEqual(b.getCompletionCacheFile(CompleteContexts), b.getCompletionCacheFile(CompleteTargetSets))
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		_, err := b.GetCompletionValues("unknown")
+		assert.Error(err)
+	})
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+// ComponentInfo is the JSON representation of a component used by a context
+type ComponentInfo struct {
+	Context   string `json:"context"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	Pack      string `json:"pack"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// ComponentDiff describes a component that differs between two contexts
+type ComponentDiff struct {
+	Component string `json:"component"`
+	Status    string `json:"status"`
+	Left      string `json:"left,omitempty"`
+	Right     string `json:"right,omitempty"`
+}
+
+const (
+	ComponentAdded   = "added"
+	ComponentRemoved = "removed"
+	ComponentChanged = "changed"
+)
+
+// getComponents returns the components of the selected contexts listed in
+// the cbuild files of an earlier setup or build
+func (b CSolutionBuilder) getComponents() (contexts []string, components []ComponentInfo, err error) {
+	_, cbuildFiles, contexts, err := b.getSelectedCbuildFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	components = []ComponentInfo{}
+	for _, context := range contexts {
+		cbuild, err := utils.ParseCbuildFile(cbuildFiles[context])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, component := range cbuild.Build.Components {
+			name, version := utils.SplitComponentID(component.Component)
+			components = append(components, ComponentInfo{
+				Context:   context,
+				Component: name,
+				Version:   version,
+				Pack:      component.FromPack,
+				Condition: component.Condition,
+			})
+		}
+	}
+	return contexts, components, nil
+}
+
+// componentLabel describes the version and origin of a component
+func componentLabel(component ComponentInfo) string {
+	label := component.Version
+	if component.Pack != "" {
+		label += " (" + component.Pack + ")"
+	}
+	return strings.TrimSpace(label)
+}
+
+// diffComponents returns the components which are only used by one of the
+// contexts or differ in version or originating pack
+func diffComponents(left, right string, components []ComponentInfo) []ComponentDiff {
+	leftComponents := make(map[string]ComponentInfo)
+	rightComponents := make(map[string]ComponentInfo)
+	var names []string
+	for _, component := range components {
+		switch component.Context {
+		case left:
+			leftComponents[component.Component] = component
+		case right:
+			rightComponents[component.Component] = component
+		default:
+			continue
+		}
+		names = utils.AppendUnique(names, component.Component)
+	}
+	sort.Strings(names)
+
+	diffs := []ComponentDiff{}
+	for _, name := range names {
+		leftComponent, inLeft := leftComponents[name]
+		rightComponent, inRight := rightComponents[name]
+		diff := ComponentDiff{Component: name}
+		if inLeft {
+			diff.Left = componentLabel(leftComponent)
+		}
+		if inRight {
+			diff.Right = componentLabel(rightComponent)
+		}
+		switch {
+		case !inLeft:
+			diff.Status = ComponentAdded
+		case !inRight:
+			diff.Status = ComponentRemoved
+		case diff.Left != diff.Right:
+			diff.Status = ComponentChanged
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// ListComponents prints the components of the selected contexts or, in diff
+// mode, the differences between the components of two contexts
+func (b CSolutionBuilder) ListComponents() error {
+	contexts, components, err := b.getComponents()
+	if err != nil {
+		return err
+	}
+
+	if b.Options.Diff {
+		if len(contexts) != 2 {
+			return errutils.New(errutils.ErrInvalidDiffContexts, len(contexts))
+		}
+		diffs := diffComponents(contexts[0], contexts[1], components)
+		if b.Options.Format == FormatJSON {
+			return printJSON(diffs)
+		}
+		if len(diffs) == 0 {
+			utils.LogStdMsg("No differences between " + contexts[0] + " and " + contexts[1])
+			return nil
+		}
+		utils.LogStdMsg("--- " + contexts[0])
+		utils.LogStdMsg("+++ " + contexts[1])
+		for _, diff := range diffs {
+			switch diff.Status {
+			case ComponentAdded:
+				utils.LogStdMsg("+ " + diff.Component + " " + diff.Right)
+			case ComponentRemoved:
+				utils.LogStdMsg("- " + diff.Component + " " + diff.Left)
+			case ComponentChanged:
+				utils.LogStdMsg("~ " + diff.Component + " " + diff.Left + " -> " + diff.Right)
+			}
+		}
+		return nil
+	}
+
+	if b.Options.Format == FormatJSON {
+		return printJSON(components)
+	}
+	for _, context := range contexts {
+		var table strings.Builder
+		writer := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+		_, _ = writer.Write([]byte("  Component\tVersion\tPack\n"))
+		for _, component := range components {
+			if component.Context == context {
+				_, _ = writer.Write([]byte("  " + component.Component + "\t" + component.Version + "\t" + component.Pack + "\n"))
+			}
+		}
+		_ = writer.Flush()
+		utils.LogStdMsg(context + ":")
+		utils.LogStdMsg(strings.TrimRight(table.String(), "\n"))
+	}
+	return nil
+}
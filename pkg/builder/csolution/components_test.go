@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListComponents(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	idx := "build-idx:\n  cbuilds:\n" +
+		"    - cbuild: test.Debug+CM0.cbuild.yml\n      project: test\n      configuration: .Debug+CM0\n" +
+		"    - cbuild: test.Release+CM0.cbuild.yml\n      project: test\n      configuration: .Release+CM0\n"
+	files := map[string]string{
+		"test.csolution.yml":  "solution:\n",
+		"test.cbuild-idx.yml": idx,
+		"test.Debug+CM0.cbuild.yml": "build:\n  components:\n" +
+			"    - component: ARM::CMSIS:CORE@5.6.0\n      from-pack: ARM::CMSIS@5.9.0\n" +
+			"    - component: ARM::CMSIS:RTOS2:Keil RTX5&Source@5.5.4\n      from-pack: ARM::CMSIS@5.9.0\n      condition: RTOS2 RTX5\n" +
+			"    - component: ARM::CMSIS-View:Event Recorder&DAP@1.5.0\n      from-pack: ARM::CMSIS-View@1.2.0\n",
+		"test.Release+CM0.cbuild.yml": "build:\n  components:\n" +
+			"    - component: ARM::CMSIS:CORE@6.0.0\n      from-pack: ARM::CMSIS@6.0.0\n" +
+			"    - component: ARM::CMSIS:RTOS2:Keil RTX5&Source@5.5.4\n      from-pack: ARM::CMSIS@5.9.0\n" +
+			"    - component: ARM::Device:Startup&C Startup@2.0.0\n      from-pack: ARM::Cortex_DFP@1.0.0\n",
+	}
+	for file, content := range files {
+		_ = os.WriteFile(filepath.Join(solutionDir, file), []byte(content), 0600)
+	}
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+		},
+	}
+
+	capture := func(b CSolutionBuilder) (string, error) {
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		err := b.ListComponents()
+		return buf.String(), err
+	}
+
+	t.Run("components of a context", func(t *testing.T) {
+		b.Options.Contexts = []string{"test.Debug+CM0"}
+		contexts, components, err := b.getComponents()
+		assert.Nil(err)
+		assert.Equal([]string{"test.Debug+CM0"}, contexts)
+		assert.Equal(3, len(components))
+		assert.Equal(ComponentInfo{
+			Context:   "test.Debug+CM0",
+			Component: "ARM::CMSIS:RTOS2:Keil RTX5&Source",
+			Version:   "5.5.4",
+			Pack:      "ARM::CMSIS@5.9.0",
+			Condition: "RTOS2 RTX5",
+		}, components[1])
+	})
+
+	t.Run("table output", func(t *testing.T) {
+		b.Options.Contexts = []string{"test.Debug+CM0"}
+		output, err := capture(b)
+		assert.Nil(err)
+		assert.Contains(output, "test.Debug+CM0:\n  Component                           Version  Pack\n")
+		assert.Contains(output, "  ARM::CMSIS:CORE                     5.6.0    ARM::CMSIS@5.9.0\n")
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		b.Options.Contexts = nil
+		b.Options.Format = FormatJSON
+		defer func() { b.Options.Format = "" }()
+		output, err := capture(b)
+		assert.Nil(err)
+		var components []ComponentInfo
+		assert.Nil(json.Unmarshal([]byte(output), &components))
+		assert.Equal(6, len(components))
+	})
+
+	t.Run("diff of two contexts", func(t *testing.T) {
+		b.Options.Contexts = []string{"test.Debug+CM0", "test.Release+CM0"}
+		b.Options.Diff = true
+		defer func() { b.Options.Diff = false }()
+		output, err := capture(b)
+		assert.Nil(err)
+		assert.Equal("--- test.Debug+CM0\n+++ test.Release+CM0\n"+
+			"- ARM::CMSIS-View:Event Recorder&DAP 1.5.0 (ARM::CMSIS-View@1.2.0)\n"+
+			"~ ARM::CMSIS:CORE 5.6.0 (ARM::CMSIS@5.9.0) -> 6.0.0 (ARM::CMSIS@6.0.0)\n"+
+			"+ ARM::Device:Startup&C Startup 2.0.0 (ARM::Cortex_DFP@1.0.0)\n", output)
+	})
+
+	t.Run("diff json output", func(t *testing.T) {
+		b.Options.Contexts = []string{"test.Release+CM0", "test.Debug+CM0"}
+		b.Options.Diff = true
+		b.Options.Format = FormatJSON
+		defer func() { b.Options.Diff = false; b.Options.Format = "" }()
+		output, err := capture(b)
+		assert.Nil(err)
+		var diffs []ComponentDiff
+		assert.Nil(json.Unmarshal([]byte(output), &diffs))
+		assert.Equal([]ComponentDiff{
+			{Component: "ARM::CMSIS-View:Event Recorder&DAP", Status: ComponentAdded, Right: "1.5.0 (ARM::CMSIS-View@1.2.0)"},
+			{Component: "ARM::CMSIS:CORE", Status: ComponentChanged, Left: "6.0.0 (ARM::CMSIS@6.0.0)", Right: "5.6.0 (ARM::CMSIS@5.9.0)"},
+			{Component: "ARM::Device:Startup&C Startup", Status: ComponentRemoved, Left: "2.0.0 (ARM::Cortex_DFP@1.0.0)"},
+		}, diffs)
+	})
+
+	t.Run("diff of identical contexts", func(t *testing.T) {
+		diffs := diffComponents("test.Debug+CM0", "test.Debug+CM1", []ComponentInfo{
+			{Context: "test.Debug+CM0", Component: "ARM::CMSIS:CORE", Version: "5.6.0"},
+			{Context: "test.Debug+CM1", Component: "ARM::CMSIS:CORE", Version: "5.6.0"},
+		})
+		assert.Empty(diffs)
+	})
+
+	t.Run("diff requires two contexts", func(t *testing.T) {
+		b.Options.Contexts = []string{"test.Debug+CM0"}
+		b.Options.Diff = true
+		defer func() { b.Options.Diff = false }()
+		_, err := capture(b)
+		assert.EqualError(err, "comparing components requires exactly two contexts, 1 selected")
+	})
+}
@@ -0,0 +1,317 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+const (
+	CoverageFormatLcov      = "lcov"
+	CoverageFormatCobertura = "cobertura"
+	DefaultGcovTool         = "gcov"
+	gcovDataExtn            = ".gcda"
+)
+
+var CoverageFormats = []string{CoverageFormatLcov, CoverageFormatCobertura}
+
+// coverageFiles maps the coverage formats to the default report file
+var coverageFiles = map[string]string{
+	CoverageFormatLcov:      "coverage.info",
+	CoverageFormatCobertura: "coverage.xml",
+}
+
+// FunctionCoverage is the execution count of a function
+type FunctionCoverage struct {
+	Name  string
+	Line  int
+	Count int64
+}
+
+// FileCoverage is the execution count of the lines and functions of a source file
+type FileCoverage struct {
+	File      string
+	Lines     map[int]int64
+	Functions map[string]*FunctionCoverage
+}
+
+// Coverage maps the absolute source file paths to their coverage
+type Coverage map[string]*FileCoverage
+
+type gcovReport struct {
+	CurrentWorkingDirectory string `json:"current_working_directory"`
+	Files                   []struct {
+		File      string `json:"file"`
+		Functions []struct {
+			Name           string `json:"name"`
+			DemangledName  string `json:"demangled_name"`
+			StartLine      int    `json:"start_line"`
+			ExecutionCount int64  `json:"execution_count"`
+		} `json:"functions"`
+		Lines []struct {
+			LineNumber int   `json:"line_number"`
+			Count      int64 `json:"count"`
+		} `json:"lines"`
+	} `json:"files"`
+}
+
+// getFile returns the coverage of a source file, creating it if needed
+func (coverage Coverage) getFile(file string) *FileCoverage {
+	fileCoverage, ok := coverage[file]
+	if !ok {
+		fileCoverage = &FileCoverage{
+			File:      file,
+			Lines:     make(map[int]int64),
+			Functions: make(map[string]*FunctionCoverage),
+		}
+		coverage[file] = fileCoverage
+	}
+	return fileCoverage
+}
+
+// getSortedFiles returns the file coverages sorted by path
+func (coverage Coverage) getSortedFiles() (files []*FileCoverage) {
+	for _, fileCoverage := range coverage {
+		files = append(files, fileCoverage)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].File < files[j].File })
+	return files
+}
+
+// getLineCounts returns the number of instrumented and executed lines
+func (fileCoverage FileCoverage) getLineCounts() (found int, hit int) {
+	for _, count := range fileCoverage.Lines {
+		found++
+		if count > 0 {
+			hit++
+		}
+	}
+	return found, hit
+}
+
+// getSortedLines returns the instrumented line numbers in ascending order
+func (fileCoverage FileCoverage) getSortedLines() []int {
+	lines := make([]int, 0, len(fileCoverage.Lines))
+	for line := range fileCoverage.Lines {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// getSortedFunctions returns the functions ordered by their start line
+func (fileCoverage FileCoverage) getSortedFunctions() (functions []*FunctionCoverage) {
+	for _, function := range fileCoverage.Functions {
+		functions = append(functions, function)
+	}
+	sort.Slice(functions, func(i, j int) bool {
+		if functions[i].Line != functions[j].Line {
+			return functions[i].Line < functions[j].Line
+		}
+		return functions[i].Name < functions[j].Name
+	})
+	return functions
+}
+
+// getLineCounts returns the number of instrumented and executed lines of all files
+func (coverage Coverage) getLineCounts() (found int, hit int) {
+	for _, fileCoverage := range coverage {
+		fileFound, fileHit := fileCoverage.getLineCounts()
+		found += fileFound
+		hit += fileHit
+	}
+	return found, hit
+}
+
+// getLineRate returns the ratio of executed lines
+func getLineRate(found int, hit int) float64 {
+	if found == 0 {
+		return 0
+	}
+	return float64(hit) / float64(found)
+}
+
+// findGcovData returns the gcov data files under the context tmp directory
+func findGcovData(contextDir string) (files []string) {
+	_ = filepath.WalkDir(contextDir, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() && filepath.Ext(path) == gcovDataExtn {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+// removeGcovData deletes the gcov data of former test runs, as gcov accumulates
+// the execution counts of consecutive runs
+func removeGcovData(contextDir string) {
+	for _, file := range findGcovData(contextDir) {
+		if err := os.Remove(file); err != nil {
+			log.Warn(err.Error())
+		}
+	}
+}
+
+// parseGcovOutput adds the line and function counts of the gcov JSON output
+// to the coverage, ignoring all files not listed in sources
+func parseGcovOutput(output string, sources map[string]bool, coverage Coverage) error {
+	decoder := json.NewDecoder(strings.NewReader(output))
+	for {
+		var report gcovReport
+		err := decoder.Decode(&report)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, file := range report.Files {
+			path := file.File
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(report.CurrentWorkingDirectory, path)
+			}
+			path = filepath.ToSlash(filepath.Clean(path))
+			if !sources[path] {
+				continue
+			}
+			fileCoverage := coverage.getFile(path)
+			for _, line := range file.Lines {
+				fileCoverage.Lines[line.LineNumber] += line.Count
+			}
+			for _, function := range file.Functions {
+				name := function.DemangledName
+				if name == "" {
+					name = function.Name
+				}
+				if _, ok := fileCoverage.Functions[name]; !ok {
+					fileCoverage.Functions[name] = &FunctionCoverage{Name: name, Line: function.StartLine}
+				}
+				fileCoverage.Functions[name].Count += function.ExecutionCount
+			}
+		}
+	}
+}
+
+// getGcovCommand returns the gcov program and the arguments for a data file. The
+// JSON output format requires gcov of GCC 9 or later, llvm-cov does not support it.
+func getGcovCommand(gcovTool string, dataFile string) (program string, args []string) {
+	if gcovTool == "" {
+		gcovTool = DefaultGcovTool
+	}
+	fields := strings.Fields(gcovTool)
+	return fields[0], append(fields[1:], "--json-format", "--stdout", dataFile)
+}
+
+// getContextTmpDir returns the context specific directory under the tmp directory
+func (b CSolutionBuilder) getContextTmpDir(context string) string {
+	return filepath.Join(b.getTmpDir(), utils.GetContextDirName(context))
+}
+
+// collectCoverage runs gcov on the data files of the contexts and returns the
+// coverage of the application sources listed in the cbuild files
+func (b CSolutionBuilder) collectCoverage(contexts []string, cbuildFiles map[string]string) (coverage Coverage, err error) {
+	coverage = make(Coverage)
+	for _, context := range contexts {
+		sources, err := getProjectSources(cbuildFiles[context])
+		if err != nil {
+			return nil, err
+		}
+		dataFiles := findGcovData(b.getContextTmpDir(context))
+		if len(dataFiles) == 0 {
+			log.Warn("no coverage data of context '" + context + "' found, build it with --coverage")
+			continue
+		}
+		for _, dataFile := range dataFiles {
+			program, args := getGcovCommand(b.Options.GcovTool, dataFile)
+			output, err := b.Runner.ExecuteCommand(program, true, args...)
+			if err != nil {
+				log.Warn(program + " failed on " + filepath.ToSlash(dataFile) + ": " + err.Error())
+				continue
+			}
+			if err := parseGcovOutput(output, sources, coverage); err != nil {
+				return nil, errutils.New(errutils.ErrInvalidCoverageData, filepath.ToSlash(dataFile), err)
+			}
+		}
+	}
+	return coverage, nil
+}
+
+// getCoverageFile returns the path of the coverage report in the output base directory
+func (b CSolutionBuilder) getCoverageFile() string {
+	return filepath.Join(b.getOutputBaseDir(), coverageFiles[b.getCoverageFormat()])
+}
+
+func (b CSolutionBuilder) getCoverageFormat() string {
+	if b.Options.CoverageFormat == "" {
+		return CoverageFormatLcov
+	}
+	return b.Options.CoverageFormat
+}
+
+// writeLcovReport writes the coverage in the lcov tracefile format
+func writeLcovReport(file string, coverage Coverage) error {
+	var report strings.Builder
+	for _, fileCoverage := range coverage.getSortedFiles() {
+		report.WriteString("TN:\n")
+		report.WriteString("SF:" + fileCoverage.File + "\n")
+		functions := fileCoverage.getSortedFunctions()
+		functionsHit := 0
+		for _, function := range functions {
+			fmt.Fprintf(&report, "FN:%d,%s\n", function.Line, function.Name)
+		}
+		for _, function := range functions {
+			fmt.Fprintf(&report, "FNDA:%d,%s\n", function.Count, function.Name)
+			if function.Count > 0 {
+				functionsHit++
+			}
+		}
+		fmt.Fprintf(&report, "FNF:%d\nFNH:%d\n", len(functions), functionsHit)
+		for _, line := range fileCoverage.getSortedLines() {
+			fmt.Fprintf(&report, "DA:%d,%d\n", line, fileCoverage.Lines[line])
+		}
+		found, hit := fileCoverage.getLineCounts()
+		fmt.Fprintf(&report, "LF:%d\nLH:%d\n", found, hit)
+		report.WriteString("end_of_record\n")
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(report.String()), 0600)
+}
+
+// writeCoverageReport writes the coverage report in the selected format and
+// prints the line coverage summary
+func (b CSolutionBuilder) writeCoverageReport(coverage Coverage) error {
+	file := b.getCoverageFile()
+	var err error
+	if b.getCoverageFormat() == CoverageFormatCobertura {
+		sourceDir, _ := filepath.Abs(filepath.Dir(b.InputFile))
+		err = writeCoberturaReport(file, coverage, sourceDir)
+	} else {
+		err = writeLcovReport(file, coverage)
+	}
+	if err != nil {
+		return err
+	}
+	found, hit := coverage.getLineCounts()
+	utils.LogStdMsg(fmt.Sprintf("coverage: %.1f%% of lines (%d/%d) in %d file(s)",
+		100*getLineRate(found, hit), hit, found, len(coverage)))
+	log.Info("coverage report written to " + filepath.ToSlash(file))
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/stretchr/testify/assert"
+)
+
+type GcovRunnerMock struct {
+	output string
+}
+
+func (r GcovRunnerMock) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	return r.output, nil
+}
+
+func TestParseGcovOutput(t *testing.T) {
+	assert := assert.New(t)
+	sources := map[string]bool{"/work/src/main.c": true, "/work/src/util.c": true}
+	output := `{"current_working_directory": "/work/tmp/test", "files": [` +
+		`{"file": "../../src/main.c", "functions": [{"name": "main", "demangled_name": "main", "start_line": 3, "execution_count": 1}],` +
+		`"lines": [{"line_number": 3, "count": 1}, {"line_number": 4, "count": 0}]},` +
+		`{"file": "/packs/startup.c", "lines": [{"line_number": 1, "count": 1}]}]}` + "\n" +
+		`{"current_working_directory": "/work/tmp/test", "files": [` +
+		`{"file": "/work/src/main.c", "lines": [{"line_number": 4, "count": 2}]}]}`
+
+	coverage := make(Coverage)
+	assert.Nil(parseGcovOutput(output, sources, coverage))
+	assert.Len(coverage, 1)
+	assert.Equal(map[int]int64{3: 1, 4: 2}, coverage["/work/src/main.c"].Lines)
+	assert.Equal(&FunctionCoverage{Name: "main", Line: 3, Count: 1}, coverage["/work/src/main.c"].Functions["main"])
+
+	assert.Error(parseGcovOutput("{invalid", sources, coverage))
+}
+
+func TestGetGcovCommand(t *testing.T) {
+	assert := assert.New(t)
+	program, args := getGcovCommand("", "main.c.gcda")
+	assert.Equal("gcov", program)
+	assert.Equal([]string{"--json-format", "--stdout", "main.c.gcda"}, args)
+
+	program, args = getGcovCommand("/opt/gcc/bin/gcov-13 -p", "main.c.gcda")
+	assert.Equal("/opt/gcc/bin/gcov-13", program)
+	assert.Equal([]string{"-p", "--json-format", "--stdout", "main.c.gcda"}, args)
+}
+
+func getTestCoverage() Coverage {
+	return Coverage{
+		"/work/src/main.c": {
+			File:      "/work/src/main.c",
+			Lines:     map[int]int64{3: 1, 4: 0, 5: 2},
+			Functions: map[string]*FunctionCoverage{"main": {Name: "main", Line: 3, Count: 1}},
+		},
+		"/work/lib/util.c": {
+			File:      "/work/lib/util.c",
+			Lines:     map[int]int64{1: 0},
+			Functions: map[string]*FunctionCoverage{"util": {Name: "util", Line: 1}},
+		},
+	}
+}
+
+func TestWriteLcovReport(t *testing.T) {
+	assert := assert.New(t)
+	file := filepath.Join(t.TempDir(), "coverage.info")
+	assert.Nil(writeLcovReport(file, getTestCoverage()))
+	data, err := os.ReadFile(file)
+	assert.Nil(err)
+	assert.Equal("TN:\nSF:/work/lib/util.c\nFN:1,util\nFNDA:0,util\nFNF:1\nFNH:0\nDA:1,0\nLF:1\nLH:0\nend_of_record\n"+
+		"TN:\nSF:/work/src/main.c\nFN:3,main\nFNDA:1,main\nFNF:1\nFNH:1\nDA:3,1\nDA:4,0\nDA:5,2\nLF:3\nLH:2\nend_of_record\n", string(data))
+}
+
+func TestCoberturaReport(t *testing.T) {
+	assert := assert.New(t)
+	sourceDir := filepath.FromSlash("/work")
+	report := getCoberturaReport(getTestCoverage(), sourceDir)
+	assert.Equal("0.5000", report.LineRate)
+	assert.Equal(2, report.LinesCovered)
+	assert.Equal(4, report.LinesValid)
+	assert.Len(report.Packages, 2)
+	assert.Equal("lib", report.Packages[0].Name)
+	assert.Equal("0.0000", report.Packages[0].LineRate)
+	assert.Equal("src", report.Packages[1].Name)
+	class := report.Packages[1].Classes[0]
+	assert.Equal("main", class.Name)
+	assert.Equal("src/main.c", class.Filename)
+	assert.Equal("0.6667", class.LineRate)
+	assert.Equal([]CoberturaLine{{Number: 3, Hits: 1}, {Number: 4}, {Number: 5, Hits: 2}}, class.Lines)
+	assert.Equal("/opt/util.c", getCoberturaFilename("/opt/util.c", sourceDir))
+
+	file := filepath.Join(t.TempDir(), "coverage.xml")
+	assert.Nil(writeCoberturaReport(file, getTestCoverage(), sourceDir))
+	data, err := os.ReadFile(file)
+	assert.Nil(err)
+	var written CoberturaCoverage
+	assert.Nil(xml.Unmarshal(data, &written))
+	assert.Equal(4, written.LinesValid)
+}
+
+func TestCollectCoverage(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	files := map[string]string{
+		"test.csolution.yml":                        "solution:\n",
+		"test.Debug+CM0.cbuild.yml":                 "build:\n  groups:\n    - group: Source\n      files:\n        - file: src/main.c\n",
+		"tmp/test.Debug+CM0/CMakeFiles/main.c.gcda": "",
+		"tmp/test.Release+CM0/main.c.gcda":          "",
+	}
+	for file, content := range files {
+		path := filepath.Join(solutionDir, file)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0600)
+	}
+	mainFile := filepath.ToSlash(filepath.Join(solutionDir, "src/main.c"))
+	output := `{"current_working_directory": "", "files": [{"file": "` + mainFile + `", "lines": [{"line_number": 2, "count": 3}]}]}`
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    GcovRunnerMock{output: output},
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+			Options:   builder.Options{Output: solutionDir},
+		},
+	}
+	cbuildFiles := map[string]string{"test.Debug+CM0": filepath.Join(solutionDir, "test.Debug+CM0.cbuild.yml")}
+
+	t.Run("collect coverage of the application sources", func(t *testing.T) {
+		coverage, err := b.collectCoverage([]string{"test.Debug+CM0"}, cbuildFiles)
+		assert.Nil(err)
+		assert.Equal(map[int]int64{2: 3}, coverage[mainFile].Lines)
+	})
+
+	t.Run("write cobertura report", func(t *testing.T) {
+		b.Options.CoverageFormat = CoverageFormatCobertura
+		defer func() { b.Options.CoverageFormat = "" }()
+		coverage, _ := b.collectCoverage([]string{"test.Debug+CM0"}, cbuildFiles)
+		assert.Nil(b.writeCoverageReport(coverage))
+		data, err := os.ReadFile(filepath.Join(solutionDir, "coverage.xml"))
+		assert.Nil(err)
+		assert.True(strings.Contains(string(data), `filename="src/main.c"`))
+	})
+
+	t.Run("remove stale coverage data", func(t *testing.T) {
+		removeGcovData(b.getContextTmpDir("test.Debug+CM0"))
+		assert.Empty(findGcovData(b.getContextTmpDir("test.Debug+CM0")))
+		assert.Len(findGcovData(b.getContextTmpDir("test.Release+CM0")), 1)
+	})
+}
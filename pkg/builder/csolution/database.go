@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+// getMergedDatabasePath returns the path of the merged compilation database,
+// relative paths are resolved against the solution directory
+func (b CSolutionBuilder) getMergedDatabasePath() string {
+	file := b.Options.MergeDatabase
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(b.InputFile), file)
+	}
+	return file
+}
+
+// getContextDatabases returns the compile_commands.json files of the contexts,
+// contexts without a database are skipped with a warning
+func (b CSolutionBuilder) getContextDatabases(contexts []string) (files map[string]string, err error) {
+	idxFile, err := b.getIdxFilePath()
+	if err != nil {
+		return nil, err
+	}
+	files = make(map[string]string)
+	for _, context := range contexts {
+		outDir, err := utils.GetOutDir(idxFile, context)
+		if err != nil {
+			return nil, err
+		}
+		compileCommandsFile := filepath.Join(outDir, "compile_commands.json")
+		if _, err := os.Stat(compileCommandsFile); err != nil {
+			log.Warn("compile_commands.json of context '" + context + "' not found, skipping it")
+			continue
+		}
+		files[context] = compileCommandsFile
+	}
+	return files, nil
+}
+
+// remapDatabase rewrites the paths of a compilation database written to file
+// according to the path mappings, and makes paths inside of the solution
+// directory relative to the directory of the database if requested
+func (b CSolutionBuilder) remapDatabase(commands []utils.CompileCommands, file string) ([]utils.CompileCommands, error) {
+	pathMaps, err := utils.ParsePathMaps(b.Options.PathMaps)
+	if err != nil {
+		return nil, err
+	}
+	var baseDir, databaseDir string
+	if b.Options.RelativePaths {
+		if baseDir, err = filepath.Abs(filepath.Dir(b.InputFile)); err != nil {
+			return nil, err
+		}
+		if databaseDir, err = filepath.Abs(filepath.Dir(file)); err != nil {
+			return nil, err
+		}
+	}
+	return utils.RemapCompileCommands(commands, pathMaps, baseDir, databaseDir), nil
+}
+
+// remapContextDatabases rewrites the paths in the compile_commands.json files
+// of the selected contexts
+func (b CSolutionBuilder) remapContextDatabases(selectedContexts []string) error {
+	files, err := b.getContextDatabases(selectedContexts)
+	if err != nil {
+		return err
+	}
+	for _, context := range selectedContexts {
+		file, ok := files[context]
+		if !ok {
+			continue
+		}
+		commands, err := utils.ParseCompileCommandsFile(file)
+		if err != nil {
+			return err
+		}
+		if commands, err = b.remapDatabase(commands, file); err != nil {
+			return err
+		}
+		if err := utils.WriteCompileCommandsFile(file, commands); err != nil {
+			return err
+		}
+		log.Debug("Remapped paths in " + filepath.ToSlash(file))
+	}
+	return nil
+}
+
+// postProcessDatabases merges the compile_commands.json files of the selected
+// contexts after the setup, and rewrites the paths of the merged and of the
+// context databases. The merged database is created from the databases of the
+// contexts before they are rewritten.
+func (b CSolutionBuilder) postProcessDatabases(selectedContexts []string) error {
+	if _, err := utils.ParsePathMaps(b.Options.PathMaps); err != nil {
+		return err
+	}
+	if b.Options.MergeDatabase != "" {
+		if err := b.mergeDatabases(selectedContexts); err != nil {
+			return err
+		}
+	}
+	if len(b.Options.PathMaps) > 0 || b.Options.RelativePaths {
+		return b.remapContextDatabases(selectedContexts)
+	}
+	return nil
+}
+
+// mergeDatabases merges the compile_commands.json files of the selected contexts
+// into a single compilation database. The database context, or else the first
+// selected context, wins for sources shared between contexts.
+func (b CSolutionBuilder) mergeDatabases(selectedContexts []string) error {
+	winner := b.Options.DatabaseContext
+	if winner == "" {
+		if len(selectedContexts) == 0 {
+			return nil
+		}
+		winner = selectedContexts[0]
+	} else if !slices.Contains(selectedContexts, winner) {
+		return utils.AddSuggestions(errutils.New(errutils.ErrInvalidDatabaseContext, winner), winner, selectedContexts)
+	}
+
+	// the database of the winner context is merged first
+	contexts := append([]string{winner}, slices.DeleteFunc(slices.Clone(selectedContexts), func(context string) bool {
+		return context == winner
+	})...)
+
+	files, err := b.getContextDatabases(contexts)
+	if err != nil {
+		return err
+	}
+	var databases []utils.ContextDatabase
+	for _, context := range contexts {
+		file, ok := files[context]
+		if !ok {
+			continue
+		}
+		commands, err := utils.ParseCompileCommandsFile(file)
+		if err != nil {
+			return err
+		}
+		databases = append(databases, utils.ContextDatabase{Context: context, Commands: commands})
+	}
+
+	merged := utils.MergeCompileCommands(databases, winner)
+	file := b.getMergedDatabasePath()
+	if len(b.Options.PathMaps) > 0 || b.Options.RelativePaths {
+		if merged, err = b.remapDatabase(merged, file); err != nil {
+			return err
+		}
+	}
+	if err := utils.WriteCompileCommandsFile(file, merged); err != nil {
+		return err
+	}
+	log.Info("Merged " + strconv.Itoa(len(databases)) + " compilation database(s) into " + filepath.ToSlash(file))
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostProcessDatabases(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	srcDir := filepath.ToSlash(solutionDir)
+	idx := "build-idx:\n  cbuilds:\n" +
+		"    - cbuild: out/test.Debug+CM0.cbuild.yml\n      project: test\n      configuration: .Debug+CM0\n" +
+		"    - cbuild: out/test.Release+CM0.cbuild.yml\n      project: test\n      configuration: .Release+CM0\n" +
+		"    - cbuild: out/test.Test+CM0.cbuild.yml\n      project: test\n      configuration: .Test+CM0\n"
+	cbuild := "build:\n  output-dirs:\n    outdir: %s\n"
+	database := `[
+  {"directory": "` + srcDir + `", "file": "main.c", "command": "gcc -D%s -c main.c"},
+  {"directory": "` + srcDir + `", "file": "%s.c", "command": "gcc -D%s -c %s.c"}
+]`
+	files := map[string]string{
+		"test.csolution.yml":                "solution:\n",
+		"test.cbuild-idx.yml":               idx,
+		"out/test.Debug+CM0.cbuild.yml":     strings.Replace(cbuild, "%s", "Debug", 1),
+		"out/test.Release+CM0.cbuild.yml":   strings.Replace(cbuild, "%s", "Release", 1),
+		"out/test.Test+CM0.cbuild.yml":      strings.Replace(cbuild, "%s", "Test", 1),
+		"out/Debug/compile_commands.json":   strings.ReplaceAll(database, "%s", "debug"),
+		"out/Release/compile_commands.json": strings.ReplaceAll(database, "%s", "release"),
+	}
+	writeFiles := func() {
+		for file, content := range files {
+			path := filepath.Join(solutionDir, file)
+			_ = os.MkdirAll(filepath.Dir(path), 0755)
+			_ = os.WriteFile(path, []byte(content), 0600)
+		}
+	}
+	writeFiles()
+	contexts := []string{"test.Debug+CM0", "test.Release+CM0", "test.Test+CM0"}
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+			Options:   builder.Options{MergeDatabase: "compile_commands.json"},
+		},
+	}
+	mergedFile := filepath.Join(solutionDir, "compile_commands.json")
+
+	t.Run("first context wins", func(t *testing.T) {
+		assert.Nil(b.mergeDatabases(contexts))
+		merged, err := utils.ParseCompileCommandsFile(mergedFile)
+		assert.Nil(err)
+		assert.Equal([]utils.CompileCommands{
+			{Directory: srcDir, File: "main.c", Command: "gcc -Ddebug -c main.c"},
+			{Directory: srcDir, File: "debug.c", Command: "gcc -Ddebug -c debug.c"},
+			{Directory: srcDir, File: "release.c", Command: "gcc -Drelease -c release.c"},
+		}, merged)
+	})
+
+	t.Run("database context wins", func(t *testing.T) {
+		b.Options.DatabaseContext = "test.Release+CM0"
+		b.Options.MergeDatabase = filepath.Join(solutionDir, "db", "compile_commands.json")
+		defer func() {
+			b.Options.DatabaseContext = ""
+			b.Options.MergeDatabase = "compile_commands.json"
+		}()
+		assert.Nil(b.mergeDatabases(contexts))
+		merged, err := utils.ParseCompileCommandsFile(b.Options.MergeDatabase)
+		assert.Nil(err)
+		assert.Equal(3, len(merged))
+		assert.Equal("gcc -Drelease -c main.c", merged[0].Command)
+	})
+
+	t.Run("database context not selected", func(t *testing.T) {
+		b.Options.DatabaseContext = "test.Release+CM3"
+		defer func() { b.Options.DatabaseContext = "" }()
+		err := b.mergeDatabases(contexts)
+		assert.EqualError(err, "context 'test.Release+CM3' of the merged database is not selected. Did you mean 'test.Release+CM0'?")
+	})
+
+	t.Run("relative paths and path mapping", func(t *testing.T) {
+		b.Options.RelativePaths = true
+		b.Options.PathMaps = []string{"gcc=/usr/bin/gcc"}
+		defer func() {
+			b.Options.RelativePaths = false
+			b.Options.PathMaps = nil
+			writeFiles()
+		}()
+		assert.Nil(b.postProcessDatabases(contexts))
+		merged, err := utils.ParseCompileCommandsFile(mergedFile)
+		assert.Nil(err)
+		assert.Equal(3, len(merged))
+		assert.Equal(utils.CompileCommands{Directory: ".", File: "main.c", Command: "/usr/bin/gcc -Ddebug -c main.c"}, merged[0])
+
+		// the databases of the contexts are rewritten relative to their directories
+		database, err := utils.ParseCompileCommandsFile(filepath.Join(solutionDir, "out", "Debug", "compile_commands.json"))
+		assert.Nil(err)
+		assert.Equal(utils.CompileCommands{Directory: "../..", File: "main.c", Command: "/usr/bin/gcc -Ddebug -c main.c"}, database[0])
+	})
+
+	t.Run("relative paths to a merged database in a subfolder", func(t *testing.T) {
+		b.Options.RelativePaths = true
+		b.Options.MergeDatabase = filepath.Join("db", "compile_commands.json")
+		defer func() {
+			b.Options.RelativePaths = false
+			b.Options.MergeDatabase = "compile_commands.json"
+			writeFiles()
+		}()
+		assert.Nil(b.postProcessDatabases(contexts))
+		merged, err := utils.ParseCompileCommandsFile(filepath.Join(solutionDir, "db", "compile_commands.json"))
+		assert.Nil(err)
+		assert.Equal("..", merged[0].Directory)
+	})
+
+	t.Run("path mapping without merged database", func(t *testing.T) {
+		b.Options.MergeDatabase = ""
+		b.Options.PathMaps = []string{srcDir + "=/src"}
+		defer func() {
+			b.Options.MergeDatabase = "compile_commands.json"
+			b.Options.PathMaps = nil
+			writeFiles()
+		}()
+		assert.Nil(os.Remove(mergedFile))
+		assert.Nil(b.postProcessDatabases(contexts))
+		assert.NoFileExists(mergedFile)
+		for _, config := range []string{"Debug", "Release"} {
+			database, err := utils.ParseCompileCommandsFile(filepath.Join(solutionDir, "out", config, "compile_commands.json"))
+			assert.Nil(err)
+			assert.Equal("/src", database[0].Directory)
+		}
+	})
+
+	t.Run("invalid path mapping", func(t *testing.T) {
+		b.Options.PathMaps = []string{"/work"}
+		defer func() { b.Options.PathMaps = nil }()
+		assert.EqualError(b.postProcessDatabases(contexts), "invalid path mapping '/work', expected <from>=<to>")
+	})
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/cbuildidx"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// CMakeVersion is the minimum CMake version required by cbuild2cmake
+const CMakeVersion = "3.25.2"
+
+// Minimum versions of the CMSIS-Toolbox 2.9.0 tools, which introduced the
+// target-sets selected with '--active'
+const (
+	CSolutionVersion    = "2.9.0"
+	Cbuild2CMakeVersion = "0.9.6"
+)
+
+// CheckResult is the outcome of a single diagnosis check
+type CheckResult struct {
+	Name    string
+	Status  string
+	Message string
+	Hint    string
+}
+
+// toolCheck describes a tool cbuild depends on
+type toolCheck struct {
+	name       string
+	installDir bool   // tool is located in the cbuild installation directory instead of the PATH
+	required   bool   // a missing tool fails the check, otherwise it is a warning
+	minVersion string // minimum version, if any
+	hint       string
+}
+
+var toolChecks = []toolCheck{
+	{name: "csolution", installDir: true, required: true, minVersion: CSolutionVersion, hint: "install the CMSIS-Toolbox 2.9.0 or higher"},
+	{name: "cbuild2cmake", installDir: true, required: true, minVersion: Cbuild2CMakeVersion, hint: "install the CMSIS-Toolbox 2.9.0 or higher"},
+	{name: "cpackget", installDir: true, hint: "reinstall the CMSIS-Toolbox to download missing packs with '--packs'"},
+	{name: "cbuildgen", installDir: true, hint: "only required with '--cbuildgen', reinstall the CMSIS-Toolbox"},
+	{name: "cmake", required: true, minVersion: CMakeVersion, hint: "install CMake " + CMakeVersion + " or higher and add it to the PATH"},
+	{name: "ninja", minVersion: cbuildidx.NinjaVersion, hint: "install Ninja " + cbuildidx.NinjaVersion + " or higher and add it to the PATH"},
+	{name: "xmllint", hint: "only required with '--cbuildgen' for schema checks, install libxml2 utilities"},
+}
+
+var versionRegex = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// checkTool checks the presence and the version of a tool
+func (b CSolutionBuilder) checkTool(tool toolCheck) CheckResult {
+	result := CheckResult{Name: tool.name, Hint: tool.hint}
+	var path string
+	if tool.installDir {
+		path = filepath.Join(b.InstallConfigs.BinPath, tool.name+b.InstallConfigs.BinExtn)
+		if _, err := os.Stat(path); err != nil {
+			path = ""
+		}
+	} else {
+		path, _ = exec.LookPath(tool.name)
+	}
+
+	if path == "" {
+		result.Status = CheckWarn
+		if tool.required {
+			result.Status = CheckFail
+		}
+		result.Message = tool.name + " not found"
+		return result
+	}
+
+	output, _ := b.Runner.ExecuteCommand(path, true, "--version")
+	toolVersion := versionRegex.FindString(output)
+	result.Status = CheckPass
+	result.Message = tool.name + " " + toolVersion + " (" + filepath.ToSlash(path) + ")"
+	if tool.minVersion != "" {
+		current, err := version.NewSemver(toolVersion)
+		minimum, _ := version.NewSemver(tool.minVersion)
+		if err != nil {
+			result.Status = CheckWarn
+			result.Message = "unable to detect the version of " + tool.name + " (" + filepath.ToSlash(path) + ")"
+		} else if current.LessThan(minimum) {
+			result.Status = CheckWarn
+			if tool.required {
+				result.Status = CheckFail
+			}
+			result.Message = tool.name + " " + toolVersion + " is older than the required version " + tool.minVersion
+		}
+	}
+	if result.Status == CheckPass {
+		result.Hint = ""
+	}
+	return result
+}
+
+// checkPackRoot checks that CMSIS_PACK_ROOT exists and is writable
+func checkPackRoot() CheckResult {
+	result := CheckResult{Name: "CMSIS_PACK_ROOT", Status: CheckFail}
+	packRoot := os.Getenv("CMSIS_PACK_ROOT")
+	if packRoot == "" {
+		packRoot = utils.GetDefaultCmsisPackRoot()
+	}
+	info, err := os.Stat(packRoot)
+	if err != nil || !info.IsDir() {
+		result.Message = "CMSIS_PACK_ROOT directory '" + filepath.ToSlash(packRoot) + "' does not exist"
+		result.Hint = "run 'cpackget init' or set CMSIS_PACK_ROOT to an existing pack directory"
+		return result
+	}
+	file, err := os.CreateTemp(packRoot, ".cbuild-doctor-*")
+	if err != nil {
+		result.Message = "CMSIS_PACK_ROOT directory '" + filepath.ToSlash(packRoot) + "' is not writable"
+		result.Hint = "grant write permission or set CMSIS_PACK_ROOT to a writable directory"
+		return result
+	}
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+	result.Status = CheckPass
+	result.Message = "CMSIS_PACK_ROOT=" + filepath.ToSlash(packRoot)
+	return result
+}
+
+// checkCompilerRoot checks that CMSIS_COMPILER_ROOT contains toolchain configuration files
+func (b CSolutionBuilder) checkCompilerRoot() CheckResult {
+	result := CheckResult{Name: "CMSIS_COMPILER_ROOT", Status: CheckFail}
+	compilerRoot := os.Getenv("CMSIS_COMPILER_ROOT")
+	if compilerRoot == "" {
+		compilerRoot = b.InstallConfigs.EtcPath
+	}
+	if _, err := os.Stat(compilerRoot); err != nil {
+		result.Message = "CMSIS_COMPILER_ROOT directory '" + filepath.ToSlash(compilerRoot) + "' does not exist"
+		result.Hint = "set CMSIS_COMPILER_ROOT to the 'etc' directory of the CMSIS-Toolbox"
+		return result
+	}
+	toolchainFiles, _ := filepath.Glob(filepath.Join(compilerRoot, "*.cmake"))
+	if len(toolchainFiles) == 0 {
+		result.Message = "CMSIS_COMPILER_ROOT directory '" + filepath.ToSlash(compilerRoot) + "' contains no toolchain configuration files"
+		result.Hint = "set CMSIS_COMPILER_ROOT to the 'etc' directory of the CMSIS-Toolbox"
+		return result
+	}
+	result.Status = CheckPass
+	result.Message = "CMSIS_COMPILER_ROOT=" + filepath.ToSlash(compilerRoot) + " (" + strconv.Itoa(len(toolchainFiles)) + " toolchain files)"
+	return result
+}
+
+// checkToolchains checks that the toolchains registered in the environment or
+// in the toolchains.yml files point to existing directories
+func checkToolchains(toolchainsEnv []string) (results []CheckResult) {
+	toolchainRegex := regexp.MustCompile(`^(\w+)_TOOLCHAIN_(\d+)_(\d+)_(\d+)$`)
+	var names []string
+	for _, env := range append(os.Environ(), toolchainsEnv...) {
+		name, _, _ := strings.Cut(env, "=")
+		if toolchainRegex.MatchString(name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return []CheckResult{{
+			Name:    "toolchains",
+			Status:  CheckWarn,
+			Message: "no toolchain registered",
+			Hint:    "register a toolchain with an environment variable <name>_TOOLCHAIN_<major>_<minor>_<patch>",
+		}}
+	}
+	for _, name := range names {
+		root := utils.GetEnv(toolchainsEnv, name)
+		result := CheckResult{Name: name, Status: CheckPass, Message: name + "=" + filepath.ToSlash(root)}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			result.Status = CheckFail
+			result.Message = name + " specifies non-existent directory '" + filepath.ToSlash(root) + "'"
+			result.Hint = "set " + name + " to the 'bin' directory of the toolchain installation"
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// checkWest checks the west setup, only when Zephyr is in use
+func checkWest() (results []CheckResult) {
+	zephyrBase := os.Getenv("ZEPHYR_BASE")
+	westBin, _ := exec.LookPath("west")
+	if zephyrBase == "" && westBin == "" {
+		return nil
+	}
+
+	result := CheckResult{Name: "west", Status: CheckPass, Message: "west (" + filepath.ToSlash(westBin) + ")"}
+	if westBin == "" {
+		result.Status = CheckFail
+		result.Message = "west not found"
+		result.Hint = "install west with 'pip install west' and add it to the PATH"
+	}
+	results = append(results, result)
+
+	result = CheckResult{Name: "ZEPHYR_BASE", Status: CheckPass, Message: "ZEPHYR_BASE=" + filepath.ToSlash(zephyrBase)}
+	if zephyrBase == "" {
+		result.Status = CheckWarn
+		result.Message = "missing ZEPHYR_BASE environment variable"
+		result.Hint = "set ZEPHYR_BASE to the 'zephyr' directory of the west workspace"
+	} else if _, err := os.Stat(zephyrBase); err != nil {
+		result.Status = CheckFail
+		result.Message = "ZEPHYR_BASE specifies non-existent directory '" + filepath.ToSlash(zephyrBase) + "'"
+		result.Hint = "set ZEPHYR_BASE to the 'zephyr' directory of the west workspace"
+	}
+	return append(results, result)
+}
+
+// RunChecks diagnoses the environment cbuild depends on
+func (b CSolutionBuilder) RunChecks() (results []CheckResult) {
+	for _, tool := range toolChecks {
+		results = append(results, b.checkTool(tool))
+	}
+	results = append(results, checkPackRoot(), b.checkCompilerRoot())
+	toolchainsEnv, err := b.getToolchainsEnv()
+	if err != nil {
+		results = append(results, CheckResult{Name: utils.ToolchainsFile, Status: CheckFail, Message: err.Error(),
+			Hint: "each toolchain entry requires 'name', 'version' as <major>.<minor>.<patch> and 'path'"})
+	}
+	results = append(results, checkToolchains(toolchainsEnv)...)
+	return append(results, checkWest()...)
+}
+
+// Doctor prints the result of each check with a hint how to fix it and
+// fails when any check failed
+func (b CSolutionBuilder) Doctor() error {
+	var passed, warnings, failed int
+	for _, result := range b.RunChecks() {
+		switch result.Status {
+		case CheckPass:
+			passed++
+		case CheckWarn:
+			warnings++
+		case CheckFail:
+			failed++
+		}
+		utils.LogStdMsg("[" + strings.ToUpper(result.Status) + "] " + result.Message)
+		if result.Hint != "" {
+			utils.LogStdMsg("       hint: " + result.Hint)
+		}
+	}
+	utils.LogStdMsg(strconv.Itoa(passed) + " passed, " + strconv.Itoa(warnings) + " warnings, " + strconv.Itoa(failed) + " failed")
+	if failed > 0 {
+		return errutils.New(errutils.ErrChecksFailed, failed)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type VersionRunnerMock struct {
+	versions map[string]string
+}
+
+func (r VersionRunnerMock) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	name := filepath.Base(program)
+	name = name[:len(name)-len(filepath.Ext(name))]
+	return r.versions[name], nil
+}
+
+func TestDoctor(t *testing.T) {
+	assert := assert.New(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("tools in the PATH are shell scripts")
+	}
+
+	// tools in the PATH
+	pathDir := t.TempDir()
+	for _, tool := range []string{"cmake", "ninja"} {
+		//nolint:gosec // G306: executable permissions required for test binary
+		_ = os.WriteFile(filepath.Join(pathDir, tool), []byte("#!/usr/bin/env bash\n"), 0755)
+	}
+	t.Setenv("PATH", pathDir)
+
+	packRoot := t.TempDir()
+	compilerRoot := t.TempDir()
+	_ = os.WriteFile(filepath.Join(compilerRoot, "GCC.13.2.1.cmake"), []byte(""), 0600)
+	toolchainRoot := t.TempDir()
+	t.Setenv("CMSIS_PACK_ROOT", packRoot)
+	t.Setenv("CMSIS_COMPILER_ROOT", compilerRoot)
+	t.Setenv("GCC_TOOLCHAIN_13_2_1", toolchainRoot)
+	t.Setenv("AC6_TOOLCHAIN_6_22_0", filepath.Join(toolchainRoot, "unknown"))
+	t.Setenv("ZEPHYR_BASE", "")
+
+	runner := VersionRunnerMock{versions: map[string]string{
+		"csolution":    "csolution 2.11.0 (C) 2025 Arm Ltd.",
+		"cbuild2cmake": "cbuild2cmake version 0.9.7",
+		"cpackget":     "cpackget version 2.1.4",
+		"cbuildgen":    "cbuildgen 2.6.0",
+		"cmake":        "cmake version 3.28.1\n\nCMake suite maintained and supported by Kitware",
+		"ninja":        "1.10.2",
+	}}
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner: runner,
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+		},
+	}
+
+	getResult := func(results []CheckResult, name string) CheckResult {
+		for _, result := range results {
+			if result.Name == name {
+				return result
+			}
+		}
+		return CheckResult{}
+	}
+
+	t.Run("run checks", func(t *testing.T) {
+		results := b.RunChecks()
+
+		csolution := getResult(results, "csolution")
+		assert.Equal(CheckPass, csolution.Status)
+		assert.Equal("csolution 2.11.0 ("+filepath.ToSlash(filepath.Join(configs.BinPath, "csolution"))+")", csolution.Message)
+		assert.Empty(csolution.Hint)
+
+		assert.Equal(CheckPass, getResult(results, "cmake").Status)
+
+		ninja := getResult(results, "ninja")
+		assert.Equal(CheckWarn, ninja.Status)
+		assert.Equal("ninja 1.10.2 is older than the required version 1.11.1", ninja.Message)
+		assert.NotEmpty(ninja.Hint)
+
+		xmllint := getResult(results, "xmllint")
+		assert.Equal(CheckWarn, xmllint.Status)
+		assert.Equal("xmllint not found", xmllint.Message)
+
+		assert.Equal(CheckPass, getResult(results, "CMSIS_PACK_ROOT").Status)
+		assert.Equal("CMSIS_COMPILER_ROOT="+filepath.ToSlash(compilerRoot)+" (1 toolchain files)", getResult(results, "CMSIS_COMPILER_ROOT").Message)
+		assert.Equal(CheckPass, getResult(results, "GCC_TOOLCHAIN_13_2_1").Status)
+		assert.Equal(CheckFail, getResult(results, "AC6_TOOLCHAIN_6_22_0").Status)
+
+		// west is not in the PATH and ZEPHYR_BASE is not set
+		assert.Empty(getResult(results, "west").Name)
+	})
+
+	t.Run("outdated cmake", func(t *testing.T) {
+		runner.versions["cmake"] = "cmake version 3.22.1"
+		defer func() { runner.versions["cmake"] = "cmake version 3.28.1" }()
+		result := b.checkTool(toolChecks[4])
+		assert.Equal(CheckFail, result.Status)
+		assert.Equal("cmake 3.22.1 is older than the required version 3.25.2", result.Message)
+	})
+
+	t.Run("outdated csolution and cbuild2cmake", func(t *testing.T) {
+		runner.versions["csolution"] = "csolution 2.6.0 (C) 2024 Arm Ltd."
+		runner.versions["cbuild2cmake"] = "cbuild2cmake version 0.9.2"
+		defer func() {
+			runner.versions["csolution"] = "csolution 2.11.0 (C) 2025 Arm Ltd."
+			runner.versions["cbuild2cmake"] = "cbuild2cmake version 0.9.7"
+		}()
+		result := b.checkTool(toolChecks[0])
+		assert.Equal(CheckResult{Name: "csolution", Status: CheckFail, Message: "csolution 2.6.0 is older than the required version 2.9.0",
+			Hint: "install the CMSIS-Toolbox 2.9.0 or higher"}, result)
+		result = b.checkTool(toolChecks[1])
+		assert.Equal(CheckFail, result.Status)
+		assert.Equal("cbuild2cmake 0.9.2 is older than the required version 0.9.6", result.Message)
+	})
+
+	t.Run("missing tool in installation directory", func(t *testing.T) {
+		result := b.checkTool(toolCheck{name: "unknown", installDir: true, required: true, hint: "reinstall"})
+		assert.Equal(CheckResult{Name: "unknown", Status: CheckFail, Message: "unknown not found", Hint: "reinstall"}, result)
+	})
+
+	t.Run("invalid pack root and compiler root", func(t *testing.T) {
+		t.Setenv("CMSIS_PACK_ROOT", filepath.Join(packRoot, "unknown"))
+		t.Setenv("CMSIS_COMPILER_ROOT", packRoot)
+		assert.Equal(CheckFail, checkPackRoot().Status)
+		result := b.checkCompilerRoot()
+		assert.Equal(CheckFail, result.Status)
+		assert.Contains(result.Message, "contains no toolchain configuration files")
+	})
+
+	t.Run("west setup", func(t *testing.T) {
+		t.Setenv("ZEPHYR_BASE", filepath.Join(packRoot, "zephyr"))
+		results := checkWest()
+		assert.Equal(2, len(results))
+		assert.Equal(CheckResult{Name: "west", Status: CheckFail, Message: "west not found",
+			Hint: "install west with 'pip install west' and add it to the PATH"}, results[0])
+		assert.Equal(CheckFail, results[1].Status)
+	})
+
+	t.Run("print results", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		err := b.Doctor()
+		// AC6_TOOLCHAIN_6_22_0 fails, further registered toolchains depend on the host
+		assert.Error(err)
+		assert.Contains(buf.String(), "[WARN] ninja 1.10.2 is older than the required version 1.11.1\n       hint: install Ninja 1.11.1 or higher and add it to the PATH\n")
+		assert.Contains(buf.String(), "[PASS] cmake 3.28.1")
+		assert.Regexp(`\d+ passed, 2 warnings, [1-9]\d* failed\n$`, buf.String())
+	})
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+// getDefaultOutDir returns the 'out' directory of the default output layout
+func (b CSolutionBuilder) getDefaultOutDir() string {
+	baseDir, _ := filepath.Abs(b.getOutputBaseDir())
+	return filepath.Join(baseDir, "out")
+}
+
+// FindOrphanDirs returns the context directories under the tmp directory and
+// the directories of the default 'out' layout that do not belong to any context
+// of the solution anymore.
+func (b CSolutionBuilder) FindOrphanDirs() (orphans []utils.PathEntry, err error) {
+	builder := b
+	builder.Options.SchemaChk = false
+	contexts, err := builder.listContexts(true, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(contexts) == 0 {
+		// without contexts every directory would be reported
+		return nil, errutils.New(errutils.ErrNoContextFound)
+	}
+
+	tmpDir := b.getTmpDir()
+	outDir := b.getDefaultOutDir()
+
+	// output directories in use by the contexts
+	var usedOutDirs []string
+	idxFile, idxErr := b.getIdxFilePath()
+	knownContexts := make(map[string]bool)
+	for _, context := range contexts {
+		knownContexts[utils.GetContextDirName(context)] = true
+
+		// default layout 'out/<project>/<target-type>/<build-type>'
+		if item, err := utils.ParseContext(context); err == nil {
+			usedOutDirs = append(usedOutDirs, filepath.Join(outDir, item.ProjectName, item.TargetType, item.BuildType))
+		}
+		if idxErr == nil {
+			if contextOutDir, err := utils.GetOutDir(idxFile, context); err == nil {
+				contextOutDir, _ = filepath.Abs(contextOutDir)
+				usedOutDirs = append(usedOutDirs, contextOutDir)
+			}
+		}
+	}
+
+	// context specific directories in the tmp directory contain the '+' of the target-type
+	if entries, err := os.ReadDir(tmpDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && strings.Contains(entry.Name(), "+") && !knownContexts[entry.Name()] {
+				path := filepath.Join(tmpDir, entry.Name())
+				orphans = append(orphans, utils.PathEntry{Path: path, Size: utils.GetDirSize(path), IsDir: true})
+			}
+		}
+	}
+
+	// the tmp directory may be located inside of the out directory
+	orphans = append(orphans, findOrphanOutDirs(outDir, append(usedOutDirs, tmpDir))...)
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Path < orphans[j].Path
+	})
+	return orphans, nil
+}
+
+// outLayoutDepth is the depth of the context directories in the default
+// layout 'out/<project>/<target-type>/<build-type>'
+const outLayoutDepth = 3
+
+// findOrphanOutDirs returns the directories of the default layout
+// 'out/<project>/<target-type>/<build-type>' which are neither one of the used
+// directories nor contain one of them. Other directories may be the custom
+// output directories of contexts missing in the cbuild-idx.yml, e.g. after a
+// setup of selected contexts, and are never reported.
+func findOrphanOutDirs(outDir string, usedDirs []string) (orphans []utils.PathEntry) {
+	isUsed := func(path string) (used bool, parent bool) {
+		for _, usedDir := range usedDirs {
+			if path == usedDir {
+				return true, false
+			}
+			if strings.HasPrefix(usedDir, path+string(filepath.Separator)) {
+				parent = true
+			}
+		}
+		return false, parent
+	}
+
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if !entry.IsDir() {
+				continue
+			}
+			used, parent := isUsed(path)
+			if used || (parent && depth == outLayoutDepth) {
+				continue
+			}
+			if depth < outLayoutDepth {
+				walk(path, depth+1)
+				continue
+			}
+			orphans = append(orphans, utils.PathEntry{Path: path, Size: utils.GetDirSize(path), IsDir: true})
+		}
+	}
+	walk(outDir, 1)
+	return orphans
+}
+
+// removeEmptyParents removes the directories between the deleted directory and
+// the base directory which became empty
+func removeEmptyParents(path string, baseDir string) {
+	for dir := filepath.Dir(path); strings.HasPrefix(dir, baseDir+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		// fails for directories which are not empty
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+// CollectGarbage lists the orphan directories of the solution and deletes them
+// when 'remove' is set. Without the cbuild-idx.yml the custom output directories
+// of the contexts are unknown, so directories are only listed but not deleted.
+func (b CSolutionBuilder) CollectGarbage(remove bool) error {
+	_, idxErr := b.getIdxFilePath()
+	if idxErr != nil && remove {
+		return errutils.New(errutils.ErrGcRequiresIdx, b.getProjectName(b.InputFile)+".cbuild-idx.yml")
+	}
+
+	orphans, err := b.FindOrphanDirs()
+	if err != nil {
+		return err
+	}
+	if idxErr != nil {
+		log.Warn("missing '" + b.getProjectName(b.InputFile) + ".cbuild-idx.yml', custom output directories may be listed as orphaned")
+	}
+	if len(orphans) == 0 {
+		utils.LogStdMsg("No orphaned directories found")
+		return nil
+	}
+
+	baseDir := filepath.Dir(b.InputFile)
+	var totalSize int64
+	utils.LogStdMsg("Orphaned directories:")
+	for _, orphan := range orphans {
+		totalSize += orphan.Size
+		utils.LogStdMsg("  " + b.displayPath(baseDir, orphan.Path) + "/ (" + utils.FormatSize(orphan.Size) + ")")
+	}
+
+	if !remove {
+		utils.LogStdMsg("Total: " + utils.FormatSize(totalSize) + ". Use '--yes' to delete the directories")
+		return nil
+	}
+
+	outDir := b.getDefaultOutDir()
+	var freedSize int64
+	var deleted int
+	for _, orphan := range orphans {
+		if err := utils.DeleteAll(orphan.Path, nil); err != nil {
+			log.Warn(err.Error())
+			continue
+		}
+		removeEmptyParents(orphan.Path, outDir)
+		freedSize += orphan.Size
+		deleted++
+	}
+	utils.LogStdMsg("Deleted " + strconv.Itoa(deleted) + " directories, freed disk space: " + utils.FormatSize(freedSize))
+	return nil
+}
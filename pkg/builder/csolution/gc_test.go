@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir, _ := filepath.Abs(filepath.Join(testRoot, testDir, "TestSolution"))
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+		},
+	}
+
+	tmpDir := filepath.Join(solutionDir, "tmpdir")
+	outDir := filepath.Join(solutionDir, "out")
+	files := map[string]bool{
+		// contexts reported by 'list contexts' are test.Debug+CM0 and test.Release+CM0
+		filepath.Join(tmpDir, "test.Debug+CM0", "CMakeCache.txt"):   false,
+		filepath.Join(tmpDir, "CMakeFiles", "cmake.check_cache"):    false,
+		filepath.Join(tmpDir, "test.Debug+CM3", "CMakeCache.txt"):   true,
+		filepath.Join(outDir, "test", "CM0", "Debug", "test.axf"):   false,
+		filepath.Join(outDir, "test", "CM0", "Release", "test.axf"): false,
+		filepath.Join(outDir, "test", "CM0", "Fast", "test.axf"):    true,
+		filepath.Join(outDir, "test", "CM3", "Debug", "test.axf"):   true,
+		filepath.Join(outDir, "old", "CM0", "Debug", "old.axf"):     true,
+		// custom output directories of contexts missing in the cbuild-idx.yml
+		filepath.Join(outDir, "custom", "app.axf"):        false,
+		filepath.Join(outDir, "custom", "arm", "app.axf"): false,
+	}
+	for file := range files {
+		_ = os.MkdirAll(filepath.Dir(file), 0755)
+		_ = os.WriteFile(file, []byte("content"), 0600)
+	}
+	defer os.RemoveAll(tmpDir)
+	defer os.RemoveAll(outDir)
+
+	t.Run("find orphan directories", func(t *testing.T) {
+		orphans, err := b.FindOrphanDirs()
+		assert.Nil(err)
+		var paths []string
+		for _, orphan := range orphans {
+			paths = append(paths, orphan.Path)
+			assert.Equal(int64(7), orphan.Size)
+		}
+		assert.Equal([]string{
+			filepath.Join(outDir, "old", "CM0", "Debug"),
+			filepath.Join(outDir, "test", "CM0", "Fast"),
+			filepath.Join(outDir, "test", "CM3", "Debug"),
+			filepath.Join(tmpDir, "test.Debug+CM3"),
+		}, paths)
+	})
+
+	t.Run("list without deleting", func(t *testing.T) {
+		err := b.CollectGarbage(false)
+		assert.Nil(err)
+		for file := range files {
+			assert.FileExists(file)
+		}
+	})
+
+	t.Run("refuse deleting without cbuild-idx", func(t *testing.T) {
+		b := b
+		b.Options.Output = t.TempDir()
+		err := b.CollectGarbage(true)
+		assert.EqualError(err, "deleting orphaned directories requires the output directories of 'test.cbuild-idx.yml', run 'cbuild setup' first")
+		for file := range files {
+			assert.FileExists(file)
+		}
+	})
+
+	t.Run("delete orphan directories", func(t *testing.T) {
+		err := b.CollectGarbage(true)
+		assert.Nil(err)
+		for file, orphan := range files {
+			if orphan {
+				assert.NoFileExists(file)
+			} else {
+				assert.FileExists(file)
+			}
+		}
+		// emptied parent directories are removed too
+		assert.NoDirExists(filepath.Join(outDir, "old"))
+		assert.NoDirExists(filepath.Join(outDir, "test", "CM3"))
+		assert.DirExists(filepath.Join(outDir, "test", "CM0"))
+	})
+}
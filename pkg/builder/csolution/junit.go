@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []JUnitTestCase `xml:"testcase"`
+	SystemOut string          `xml:"system-out,omitempty"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      string        `xml:"line,attr,omitempty"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Error     *JUnitMessage `xml:"error,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+}
+
+type JUnitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+func formatSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+// getJUnitReport converts the test results into a JUnit report with a test
+// suite per context
+func getJUnitReport(results []TestResult) JUnitTestSuites {
+	report := JUnitTestSuites{Name: "cbuild test"}
+	var total time.Duration
+	for _, result := range results {
+		suite := JUnitTestSuite{
+			Name:      result.Context,
+			Time:      formatSeconds(result.Duration),
+			Timestamp: result.Start.Format(time.RFC3339),
+			SystemOut: result.Output,
+		}
+		for _, test := range result.Cases {
+			testCase := JUnitTestCase{
+				Name:      test.Name,
+				Classname: result.Context,
+				File:      test.File,
+				Time:      formatSeconds(test.Duration),
+			}
+			if test.Line > 0 {
+				testCase.Line = strconv.Itoa(test.Line)
+			}
+			switch test.Status {
+			case TestFailed:
+				testCase.Failure = &JUnitMessage{Message: test.Message}
+				suite.Failures++
+			case TestError:
+				testCase.Error = &JUnitMessage{Message: test.Message}
+				suite.Errors++
+			case TestSkipped:
+				testCase.Skipped = &JUnitMessage{Message: test.Message}
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		total += result.Duration
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = formatSeconds(total)
+	return report
+}
+
+// writeJUnitReport writes the test results as JUnit XML file
+func writeJUnitReport(file string, results []TestResult) error {
+	data, err := xml.MarshalIndent(getJUnitReport(results), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, append([]byte(xml.Header), append(data, '\n')...), 0600)
+}
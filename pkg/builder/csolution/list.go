@@ -0,0 +1,291 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ContextInfo is the JSON representation of a context
+type ContextInfo struct {
+	Context    string `json:"context"`
+	Project    string `json:"project"`
+	BuildType  string `json:"buildType"`
+	TargetType string `json:"targetType"`
+	Cbuild     string `json:"cbuild,omitempty"`
+	OutDir     string `json:"outDir,omitempty"`
+}
+
+// TargetSetInfo is the JSON representation of a target-set
+type TargetSetInfo struct {
+	TargetSet  string `json:"targetSet"`
+	TargetType string `json:"targetType"`
+	Set        string `json:"set"`
+}
+
+// ToolInfo is the JSON representation of an installed tool
+type ToolInfo struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// EnvironmentInfo is the JSON representation of the environment configurations
+type EnvironmentInfo struct {
+	Variables map[string]string `json:"variables"`
+	Tools     []ToolInfo        `json:"tools"`
+}
+
+// OutputInfo is the JSON representation of a build artifact of a context
+type OutputInfo struct {
+	Context string `json:"context"`
+	Type    string `json:"type"`
+	File    string `json:"file"`
+	Exists  bool   `json:"exists"`
+}
+
+// printJSON prints the value as indented JSON
+func printJSON(value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	utils.LogStdMsg(string(data))
+	return nil
+}
+
+// getToolInfo returns the path and the version of an installed tool
+func (b CSolutionBuilder) getToolInfo(name string) (info ToolInfo) {
+	info.Name = name
+	path, err := utils.GetInstalledExePath(name)
+	if err != nil || path == "" {
+		return info
+	}
+	info.Path = path
+
+	// run "exe --version" command
+	versionStr, err := b.Runner.ExecuteCommand(path, true, "--version")
+	if err != nil {
+		return info
+	}
+
+	// get version
+	if name == "cmake" {
+		re := regexp.MustCompile(`version\s(.*?)\s`)
+		if match := re.FindStringSubmatch(versionStr); match != nil {
+			info.Version = match[1]
+		}
+	} else {
+		info.Version = strings.TrimSpace(versionStr)
+	}
+	return info
+}
+
+// getCbuildFiles maps the contexts of the cbuild-idx file to their cbuild files
+func getCbuildFiles(idxFile string) (cbuildFiles map[string]string, contexts []string) {
+	cbuildFiles = make(map[string]string)
+	data, err := utils.ParseCbuildIndexFile(idxFile)
+	if err != nil {
+		return
+	}
+	for _, cbuild := range data.BuildIdx.Cbuilds {
+		context := cbuild.Project + cbuild.Configuration
+		cbuildFiles[context] = filepath.ToSlash(filepath.Join(filepath.Dir(idxFile), cbuild.Cbuild))
+		contexts = append(contexts, context)
+	}
+	return
+}
+
+// getSelectedCbuildFiles returns the cbuild-idx file, the cbuild files of its
+// contexts and the contexts matching the selection of an earlier setup or build
+func (b CSolutionBuilder) getSelectedCbuildFiles() (idxFile string, cbuildFiles map[string]string, contexts []string, err error) {
+	idxFile, err = b.getIdxFilePath()
+	if err != nil {
+		return
+	}
+	cbuildFiles, contexts = getCbuildFiles(idxFile)
+	if len(b.Options.Contexts) == 0 && len(b.Options.ExcludeContexts) == 0 {
+		return
+	}
+	selection := utils.ContextSelection{Exclude: b.Options.ExcludeContexts, AllowEmpty: b.Options.AllowEmpty}
+	contexts, err = utils.SelectContexts(contexts, b.Options.Contexts, selection)
+	return
+}
+
+// getOutputs returns the build artifacts of the selected contexts listed in
+// the cbuild files of an earlier setup or build
+func (b CSolutionBuilder) getOutputs() (outputs []OutputInfo, err error) {
+	idxFile, cbuildFiles, contexts, err := b.getSelectedCbuildFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	outputs = []OutputInfo{}
+	for _, context := range contexts {
+		cbuild, err := utils.ParseCbuildFile(cbuildFiles[context])
+		if err != nil {
+			return nil, err
+		}
+		outDir, err := utils.GetOutDir(idxFile, context)
+		if err != nil {
+			return nil, err
+		}
+		for _, output := range cbuild.Build.Output {
+			file := output.File
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(outDir, file)
+			}
+			_, statErr := os.Stat(file)
+			outputs = append(outputs, OutputInfo{
+				Context: context,
+				Type:    output.Type,
+				File:    filepath.ToSlash(file),
+				Exists:  statErr == nil,
+			})
+		}
+	}
+	return outputs, nil
+}
+
+// ListOutputs prints the build artifacts of the selected contexts
+func (b CSolutionBuilder) ListOutputs() error {
+	outputs, err := b.getOutputs()
+	if err != nil {
+		return err
+	}
+	if b.Options.Format == FormatJSON {
+		return printJSON(outputs)
+	}
+	var context string
+	for _, output := range outputs {
+		if output.Context != context {
+			context = output.Context
+			utils.LogStdMsg(context + ":")
+		}
+		line := "  " + output.Type + ": " + output.File
+		if !output.Exists {
+			line += " (missing)"
+		}
+		utils.LogStdMsg(line)
+	}
+	return nil
+}
+
+// getContextInfos enriches the contexts with the cbuild files and output
+// directories of an earlier setup or build
+func (b CSolutionBuilder) getContextInfos(contexts []string) []ContextInfo {
+	cbuildFiles := make(map[string]string)
+	idxFile, idxErr := b.getIdxFilePath()
+	if idxErr == nil {
+		cbuildFiles, _ = getCbuildFiles(idxFile)
+	}
+
+	infos := []ContextInfo{}
+	for _, context := range contexts {
+		info := ContextInfo{Context: context, Cbuild: cbuildFiles[context]}
+		if item, err := utils.ParseContext(context); err == nil {
+			info.Project = item.ProjectName
+			info.BuildType = item.BuildType
+			info.TargetType = item.TargetType
+		}
+		if info.Cbuild != "" {
+			if outDir, err := utils.GetOutDir(idxFile, context); err == nil {
+				info.OutDir = filepath.ToSlash(outDir)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// parseToolchains parses the verbose output of 'csolution list toolchains',
+// toolchain roots are looked up in env before the process environment
+func parseToolchains(output string, env []string) []utils.ToolchainInfo {
+	toolchains := []utils.ToolchainInfo{}
+	for _, line := range strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			name, version, _ := strings.Cut(strings.TrimSpace(line), "@")
+			toolchains = append(toolchains, utils.ToolchainInfo{Name: name, Version: version})
+			continue
+		}
+		if len(toolchains) == 0 {
+			continue
+		}
+		if root, ok := strings.CutPrefix(strings.TrimSpace(line), "Toolchain:"); ok {
+			toolchains[len(toolchains)-1].Root = strings.TrimSpace(root)
+		} else if envVar, ok := strings.CutPrefix(strings.TrimSpace(line), "Environment:"); ok && toolchains[len(toolchains)-1].Root == "" {
+			toolchains[len(toolchains)-1].Root = utils.GetEnv(env, strings.TrimSpace(envVar))
+		}
+	}
+	return toolchains
+}
+
+func (b CSolutionBuilder) listContextsJSON() error {
+	contexts, err := b.listContexts(true, false)
+	if err != nil {
+		return err
+	}
+	return printJSON(b.getContextInfos(contexts))
+}
+
+func (b CSolutionBuilder) listToolchainsJSON() error {
+	builder := b
+	builder.Options.Verbose = true
+	output, err := builder.runCSolution(builder.formulateArgs([]string{"list", "toolchains"}), true)
+	if err != nil {
+		return err
+	}
+	env, err := builder.getToolchainsEnv()
+	if err != nil {
+		return err
+	}
+	return printJSON(parseToolchains(output, env))
+}
+
+func (b CSolutionBuilder) listTargetSetsJSON() error {
+	targetSets, err := b.listTargetSets(true)
+	if err != nil {
+		return err
+	}
+	infos := []TargetSetInfo{}
+	for _, targetSet := range targetSets {
+		targetType, set, _ := strings.Cut(targetSet, "@")
+		infos = append(infos, TargetSetInfo{TargetSet: targetSet, TargetType: targetType, Set: set})
+	}
+	return printJSON(infos)
+}
+
+func (b CSolutionBuilder) listEnvironmentJSON() error {
+	envConfigs, err := b.listCSolutionEnvironment(true)
+	if err != nil {
+		return err
+	}
+	info := EnvironmentInfo{Variables: make(map[string]string)}
+	for _, config := range envConfigs {
+		if name, value, ok := strings.Cut(config, "="); ok {
+			info.Variables[name] = value
+		}
+	}
+	for _, tool := range environmentTools {
+		info.Tools = append(info.Tools, b.getToolInfo(tool))
+	}
+	return printJSON(info)
+}
@@ -0,0 +1,278 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// environmentRunnerMock reports the versions of cmake and ninja
+type environmentRunnerMock struct {
+	RunnerMock
+}
+
+func (r environmentRunnerMock) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	switch filepath.Base(program) {
+	case "cmake":
+		return "cmake version 3.28.1\n\nCMake suite maintained and supported by Kitware (kitware.com/cmake).\n", nil
+	case "ninja":
+		return "1.12.1\n", nil
+	}
+	return r.RunnerMock.ExecuteCommand(program, quiet, args...)
+}
+
+func TestListJSON(t *testing.T) {
+	assert := assert.New(t)
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml"),
+			Options: builder.Options{
+				Format: FormatJSON,
+			},
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+		},
+	}
+
+	capture := func(list func() error) ([]byte, error) {
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		err := list()
+		return buf.Bytes(), err
+	}
+
+	t.Run("test list contexts", func(t *testing.T) {
+		output, err := capture(b.ListContexts)
+		assert.Nil(err)
+		var contexts []ContextInfo
+		assert.Nil(json.Unmarshal(output, &contexts))
+		assert.Equal(2, len(contexts))
+		assert.Equal(ContextInfo{Context: "test.Debug+CM0", Project: "test", BuildType: "Debug", TargetType: "CM0"}, contexts[0])
+		assert.Equal("Release", contexts[1].BuildType)
+	})
+
+	t.Run("test list toolchains", func(t *testing.T) {
+		output, err := capture(b.ListToolchains)
+		assert.Nil(err)
+		var toolchains []utils.ToolchainInfo
+		assert.Nil(json.Unmarshal(output, &toolchains))
+		assert.Equal(4, len(toolchains))
+		assert.Equal(utils.ToolchainInfo{Name: "AC6", Version: "6.18.0"}, toolchains[1])
+	})
+
+	t.Run("test list environment", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("tools in the PATH are shell scripts")
+		}
+		pathDir := t.TempDir()
+		for _, tool := range []string{"cmake", "ninja"} {
+			//nolint:gosec // G306: executable permissions required for test binary
+			_ = os.WriteFile(filepath.Join(pathDir, tool), []byte("#!/usr/bin/env bash\n"), 0755)
+		}
+		t.Setenv("PATH", pathDir)
+
+		b := b
+		b.Runner = environmentRunnerMock{}
+		output, err := capture(b.ListEnvironment)
+		assert.Nil(err)
+		assert.JSONEq(`{
+			"variables": {"CMSIS_PACK_ROOT": "C:/Path/Packs", "CMSIS_COMPILER_ROOT": "C:/Test/etc"},
+			"tools": [
+				{"name": "cmake", "path": "`+filepath.Join(pathDir, "cmake")+`", "version": "3.28.1"},
+				{"name": "ninja", "path": "`+filepath.Join(pathDir, "ninja")+`", "version": "1.12.1"}
+			]
+		}`, string(output))
+	})
+
+	t.Run("test list environment without tools", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		output, err := capture(b.ListEnvironment)
+		assert.Nil(err)
+		assert.JSONEq(`{
+			"variables": {"CMSIS_PACK_ROOT": "C:/Path/Packs", "CMSIS_COMPILER_ROOT": "C:/Test/etc"},
+			"tools": [{"name": "cmake", "path": "", "version": ""}, {"name": "ninja", "path": "", "version": ""}]
+		}`, string(output))
+	})
+
+	t.Run("test list target-sets", func(t *testing.T) {
+		output, err := capture(b.ListTargetSets)
+		assert.Nil(err)
+		var targetSets []TargetSetInfo
+		assert.Nil(json.Unmarshal(output, &targetSets))
+		assert.Empty(targetSets)
+	})
+}
+
+func TestParseToolchains(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("GCC_TOOLCHAIN_13_2_1", "/path/to/gcc")
+	output := "AC6@6.22.0\n  Environment: AC6_TOOLCHAIN_6_22_0\n  Toolchain: /path/to/ac6/bin\n" +
+		"GCC@13.2.1\r\n  Environment: GCC_TOOLCHAIN_13_2_1\r\n" +
+		"IAR@9.50.1\n"
+	toolchains := parseToolchains(output, nil)
+	assert.Equal([]utils.ToolchainInfo{
+		{Name: "AC6", Version: "6.22.0", Root: "/path/to/ac6/bin"},
+		{Name: "GCC", Version: "13.2.1", Root: "/path/to/gcc"},
+		{Name: "IAR", Version: "9.50.1"},
+	}, toolchains)
+
+	assert.Empty(parseToolchains("", nil))
+
+	// toolchains registered in toolchains.yml
+	toolchains = parseToolchains("GCC@13.2.1\n  Environment: GCC_TOOLCHAIN_13_2_1\n", []string{"GCC_TOOLCHAIN_13_2_1=/solution/gcc"})
+	assert.Equal([]utils.ToolchainInfo{{Name: "GCC", Version: "13.2.1", Root: "/solution/gcc"}}, toolchains)
+}
+
+func TestListOutputs(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	idx := "build-idx:\n  cbuilds:\n" +
+		"    - cbuild: out/test.Debug+CM0.cbuild.yml\n      project: test\n      configuration: .Debug+CM0\n" +
+		"    - cbuild: out/test.Release+CM0.cbuild.yml\n      project: test\n      configuration: .Release+CM0\n"
+	cbuild := "build:\n  output-dirs:\n    outdir: %s\n  output:\n    - type: elf\n      file: test.axf\n    - type: hex\n      file: test.hex\n"
+	files := map[string]string{
+		"test.csolution.yml":              "solution:\n",
+		"test.cbuild-idx.yml":             idx,
+		"out/test.Debug+CM0.cbuild.yml":   strings.Replace(cbuild, "%s", "Debug", 1),
+		"out/test.Release+CM0.cbuild.yml": strings.Replace(cbuild, "%s", "Release", 1),
+		"out/Debug/test.axf":              "elf",
+		"out/Release/test.axf":            "elf",
+		"out/Release/test.hex":            "hex",
+	}
+	for file, content := range files {
+		path := filepath.Join(solutionDir, file)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0600)
+	}
+	outDir := filepath.ToSlash(filepath.Join(solutionDir, "out"))
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+		},
+	}
+
+	t.Run("all contexts", func(t *testing.T) {
+		outputs, err := b.getOutputs()
+		assert.Nil(err)
+		assert.Equal([]OutputInfo{
+			{Context: "test.Debug+CM0", Type: "elf", File: outDir + "/Debug/test.axf", Exists: true},
+			{Context: "test.Debug+CM0", Type: "hex", File: outDir + "/Debug/test.hex", Exists: false},
+			{Context: "test.Release+CM0", Type: "elf", File: outDir + "/Release/test.axf", Exists: true},
+			{Context: "test.Release+CM0", Type: "hex", File: outDir + "/Release/test.hex", Exists: true},
+		}, outputs)
+	})
+
+	t.Run("selected context", func(t *testing.T) {
+		b.Options.Contexts = []string{".Release"}
+		defer func() { b.Options.Contexts = nil }()
+		outputs, err := b.getOutputs()
+		assert.Nil(err)
+		assert.Equal(2, len(outputs))
+		assert.Equal("test.Release+CM0", outputs[0].Context)
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		b.Options.Contexts = []string{"unknown"}
+		defer func() { b.Options.Contexts = nil }()
+		_, err := b.getOutputs()
+		assert.Error(err)
+	})
+
+	t.Run("text output", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		err := b.ListOutputs()
+		assert.Nil(err)
+		assert.Contains(buf.String(), "test.Debug+CM0:\n  elf: "+outDir+"/Debug/test.axf\n  hex: "+outDir+"/Debug/test.hex (missing)\n")
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		b.Options.Format = FormatJSON
+		defer func() { b.Options.Format = "" }()
+		err := b.ListOutputs()
+		assert.Nil(err)
+		var outputs []OutputInfo
+		assert.Nil(json.Unmarshal(buf.Bytes(), &outputs))
+		assert.Equal(4, len(outputs))
+	})
+
+	t.Run("missing cbuild-idx", func(t *testing.T) {
+		_ = os.Remove(filepath.Join(solutionDir, "test.cbuild-idx.yml"))
+		_, err := b.getOutputs()
+		assert.Error(err)
+	})
+}
+
+// envRunnerMock records the environment passed to the executed programs
+type envRunnerMock struct {
+	RunnerMock
+	env *[]string
+}
+
+func (r envRunnerMock) WithEnv(env []string) utils.RunnerInterface {
+	*r.env = env
+	return r
+}
+
+func TestListToolchainsRegistration(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", solutionDir)
+	t.Setenv("GCC_TOOLCHAIN_13_2_1", "")
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+			InstallConfigs: utils.Configurations{
+				BinPath: configs.BinPath,
+				BinExtn: configs.BinExtn,
+				EtcPath: configs.EtcPath,
+			},
+		},
+	}
+
+	t.Run("toolchains of the solution are registered", func(t *testing.T) {
+		_ = os.WriteFile(filepath.Join(solutionDir, utils.ToolchainsFile), []byte("toolchains:\n  - name: GCC\n    version: 13.2.1\n    path: gcc/bin\n"), 0600)
+		var env []string
+		b.Runner = envRunnerMock{env: &env}
+		err := b.ListToolchains()
+		assert.Nil(err)
+		assert.Equal([]string{"GCC_TOOLCHAIN_13_2_1=" + filepath.Join(solutionDir, "gcc/bin")}, env)
+		assert.Empty(os.Getenv("GCC_TOOLCHAIN_13_2_1"))
+	})
+
+	t.Run("invalid toolchains.yml", func(t *testing.T) {
+		_ = os.WriteFile(filepath.Join(solutionDir, utils.ToolchainsFile), []byte("toolchains:\n  - name: GCC\n    version: 13\n    path: gcc/bin\n"), 0600)
+		err := b.ListToolchains()
+		assert.ErrorContains(err, "invalid toolchain entry")
+	})
+}
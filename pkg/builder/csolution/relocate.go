@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// BuildTreeInfoFile records the solution directory the build tree in the tmp
+// directory was generated for
+const BuildTreeInfoFile = "cbuild-tree.yml"
+
+type buildTreeInfo struct {
+	SolutionDir string `yaml:"solution-dir"`
+}
+
+// recordSolutionDir records the current solution directory in the tmp directory,
+// when it contains a generated build tree
+func (b CSolutionBuilder) recordSolutionDir() error {
+	tmpDir := b.getTmpDir()
+	if _, err := os.Stat(filepath.Join(tmpDir, "CMakeCache.txt")); err != nil {
+		return nil
+	}
+	solutionDir, _ := filepath.Abs(filepath.Dir(b.InputFile))
+	data, err := yaml.Marshal(buildTreeInfo{SolutionDir: filepath.ToSlash(solutionDir)})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(tmpDir, BuildTreeInfoFile), data, 0600)
+}
+
+// getRecordedSolutionDir returns the solution directory recorded by recordSolutionDir
+func (b CSolutionBuilder) getRecordedSolutionDir() (string, bool) {
+	// #nosec G304 file path is generated by cbuild
+	data, err := os.ReadFile(filepath.Join(b.getTmpDir(), BuildTreeInfoFile))
+	if err != nil {
+		return "", false
+	}
+	var info buildTreeInfo
+	if err := yaml.Unmarshal(data, &info); err != nil || info.SolutionDir == "" {
+		return "", false
+	}
+	return info.SolutionDir, true
+}
+
+// getRelocation returns the former and the current directory of the solution.
+// The former directory is the one recorded at the last build. Build trees without
+// record fall back to the build directory in the CMake cache, assuming the solution
+// was moved together with its tmp directory located inside the solution.
+func (b CSolutionBuilder) getRelocation() (oldRoot string, newRoot string, err error) {
+	tmpDir := b.getTmpDir()
+	cmakeCacheFile := filepath.Join(tmpDir, "CMakeCache.txt")
+	if _, err := utils.FileExists(cmakeCacheFile); err != nil {
+		return "", "", err
+	}
+
+	newRoot, _ = filepath.Abs(filepath.Dir(b.InputFile))
+	if recorded, ok := b.getRecordedSolutionDir(); ok {
+		return recorded, filepath.ToSlash(newRoot), nil
+	}
+
+	relRoot, err := filepath.Rel(tmpDir, newRoot)
+	if err != nil || !isParentPath(relRoot) {
+		return "", "", errutils.New(errutils.ErrUnknownSolutionDir, filepath.ToSlash(tmpDir))
+	}
+	oldTmpDir, err := utils.GetCMakeCacheFileDir(cmakeCacheFile)
+	if err != nil {
+		return "", "", err
+	}
+	oldRoot = filepath.Join(filepath.FromSlash(oldTmpDir), relRoot)
+	return filepath.ToSlash(oldRoot), filepath.ToSlash(newRoot), nil
+}
+
+// isParentPath reports whether the relative path only leads to the same or to
+// parent directories
+func isParentPath(relPath string) bool {
+	for _, elem := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if elem != ".." && elem != "." {
+			return false
+		}
+	}
+	return true
+}
+
+// Relocate updates the absolute paths in the tmp directory after the solution was
+// moved, so that the next build continues incrementally instead of a full rebuild
+func (b CSolutionBuilder) Relocate(dryRun bool) error {
+	oldRoot, newRoot, err := b.getRelocation()
+	if err != nil {
+		return err
+	}
+	if equal, _ := utils.ComparePaths(oldRoot, newRoot); equal {
+		utils.LogStdMsg("Build tree is up to date, no relocation needed")
+		return nil
+	}
+
+	tmpDir := b.getTmpDir()
+	updated, err := utils.RelocateBuildTree(tmpDir, oldRoot, newRoot, dryRun)
+	if err != nil {
+		return err
+	}
+
+	operation := "Relocating"
+	if dryRun {
+		operation = "Would relocate"
+	}
+	utils.LogStdMsg(operation + " build tree from '" + oldRoot + "' to '" + newRoot + "'")
+	baseDir := filepath.Dir(b.InputFile)
+	for _, file := range updated {
+		utils.LogStdMsg("  " + b.displayPath(baseDir, file))
+	}
+	if dryRun {
+		utils.LogStdMsg(strconv.Itoa(len(updated)) + " files to be updated")
+		return nil
+	}
+	utils.LogStdMsg("Updated " + strconv.Itoa(len(updated)) + " files")
+	return b.recordSolutionDir()
+}
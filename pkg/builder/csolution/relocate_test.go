@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelocate(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir, _ := filepath.Abs(filepath.Join(testRoot, testDir, "TestSolution"))
+	solutionDir = filepath.ToSlash(solutionDir)
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:    RunnerMock{},
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+		},
+	}
+
+	tmpDir := filepath.Join(solutionDir, "tmpdir")
+	cmakeCacheFile := filepath.Join(tmpDir, "CMakeCache.txt")
+	buildFile := filepath.Join(tmpDir, "test.Debug+CM0", "build.ninja")
+	defer os.RemoveAll(tmpDir)
+
+	writeBuildTree := func(root string) {
+		_ = os.RemoveAll(tmpDir)
+		_ = os.MkdirAll(filepath.Dir(buildFile), 0755)
+		_ = os.WriteFile(cmakeCacheFile, []byte("CMAKE_CACHEFILE_DIR:INTERNAL="+root+"/tmpdir\n"+
+			"CMAKE_HOME_DIRECTORY:INTERNAL="+root+"/tmpdir\n"), 0600)
+		_ = os.WriteFile(buildFile, []byte("build main.o: C_COMPILER "+root+"/main.c\n"+
+			"include /opt/packs/ARM/CMSIS/rules.ninja\n"), 0600)
+	}
+
+	t.Run("missing build tree", func(t *testing.T) {
+		_ = os.RemoveAll(tmpDir)
+		assert.Error(b.Relocate(false))
+	})
+
+	t.Run("build tree not moved", func(t *testing.T) {
+		writeBuildTree(solutionDir)
+		assert.Nil(b.Relocate(false))
+		assert.Empty(b.getProjectMovedReason())
+	})
+
+	t.Run("dry run keeps moved build tree", func(t *testing.T) {
+		writeBuildTree("/home/user/old/TestSolution")
+		oldRoot, newRoot, err := b.getRelocation()
+		assert.Nil(err)
+		assert.Equal("/home/user/old/TestSolution", oldRoot)
+		assert.Equal(solutionDir, newRoot)
+
+		assert.Nil(b.Relocate(true))
+		assert.NotEmpty(b.getProjectMovedReason())
+	})
+
+	t.Run("relocate moved build tree", func(t *testing.T) {
+		writeBuildTree("/home/user/old/TestSolution")
+		assert.Nil(b.Relocate(false))
+		assert.Empty(b.getProjectMovedReason())
+
+		content, _ := os.ReadFile(buildFile)
+		assert.Equal("build main.o: C_COMPILER "+solutionDir+"/main.c\n"+
+			"include /opt/packs/ARM/CMSIS/rules.ninja\n", string(content))
+	})
+
+	t.Run("relocate records the solution directory", func(t *testing.T) {
+		writeBuildTree("/home/user/old/TestSolution")
+		assert.Nil(b.Relocate(false))
+		recorded, ok := b.getRecordedSolutionDir()
+		assert.True(ok)
+		assert.Equal(solutionDir, recorded)
+	})
+
+	t.Run("tmp directory outside of the solution", func(t *testing.T) {
+		outside := b
+		outside.Options.Output = filepath.ToSlash(t.TempDir())
+		outsideTmpDir := outside.getTmpDir()
+		outsideBuildFile := filepath.Join(outsideTmpDir, "test.Debug+CM0", "build.ninja")
+		_ = os.MkdirAll(filepath.Dir(outsideBuildFile), 0755)
+		_ = os.WriteFile(filepath.Join(outsideTmpDir, "CMakeCache.txt"),
+			[]byte("CMAKE_CACHEFILE_DIR:INTERNAL="+filepath.ToSlash(outsideTmpDir)+"\n"), 0600)
+		_ = os.WriteFile(outsideBuildFile, []byte("build main.o: C_COMPILER /home/user/old/TestSolution/main.c\n"), 0600)
+
+		// the CMake cache does not tell where the solution was located
+		_, _, err := outside.getRelocation()
+		assert.Error(err)
+		assert.Error(outside.Relocate(false))
+
+		_ = os.WriteFile(filepath.Join(outsideTmpDir, BuildTreeInfoFile),
+			[]byte("solution-dir: /home/user/old/TestSolution\n"), 0600)
+		oldRoot, newRoot, err := outside.getRelocation()
+		assert.Nil(err)
+		assert.Equal("/home/user/old/TestSolution", oldRoot)
+		assert.Equal(solutionDir, newRoot)
+
+		assert.Nil(outside.Relocate(false))
+		content, _ := os.ReadFile(outsideBuildFile)
+		assert.Equal("build main.o: C_COMPILER "+solutionDir+"/main.c\n", string(content))
+		recorded, _ := outside.getRecordedSolutionDir()
+		assert.Equal(solutionDir, recorded)
+	})
+}
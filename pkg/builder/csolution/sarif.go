@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool        SARIFTool         `json:"tool"`
+	Invocations []SARIFInvocation `json:"invocations"`
+	Results     []SARIFResult     `json:"results"`
+}
+
+type SARIFInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []SARIFNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type SARIFNotification struct {
+	Level   string       `json:"level"`
+	Message SARIFMessage `json:"message"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name  string      `json:"name"`
+	Rules []SARIFRule `json:"rules,omitempty"`
+}
+
+type SARIFRule struct {
+	ID string `json:"id"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           SARIFRegion           `json:"region"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// getSARIFLevel maps the severity of a finding to a SARIF level
+func getSARIFLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "note", "information":
+		return "note"
+	}
+	return "warning"
+}
+
+// getSARIF converts the findings of an analyzer into a SARIF log, the failures
+// of the analyzer mark the execution as unsuccessful
+func getSARIF(analyzer string, findings []Finding, failures []string) SARIFLog {
+	invocation := SARIFInvocation{ExecutionSuccessful: len(failures) == 0}
+	for _, failure := range failures {
+		invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications,
+			SARIFNotification{Level: "error", Message: SARIFMessage{Text: failure}})
+	}
+	run := SARIFRun{
+		Tool:        SARIFTool{Driver: SARIFDriver{Name: analyzer}},
+		Invocations: []SARIFInvocation{invocation},
+		Results:     []SARIFResult{},
+	}
+	rules := make(map[string]bool)
+	for _, finding := range findings {
+		if finding.Check != "" && !rules[finding.Check] {
+			rules[finding.Check] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SARIFRule{ID: finding.Check})
+		}
+		run.Results = append(run.Results, SARIFResult{
+			RuleID:  finding.Check,
+			Level:   getSARIFLevel(finding.Severity),
+			Message: SARIFMessage{Text: finding.Message},
+			Locations: []SARIFLocation{{PhysicalLocation: SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{URI: getSARIFURI(finding.File)},
+				Region:           SARIFRegion{StartLine: finding.Line, StartColumn: finding.Column},
+			}}},
+		})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+	return SARIFLog{Schema: SARIFSchema, Version: SARIFVersion, Runs: []SARIFRun{run}}
+}
+
+// getSARIFURI returns the file URI of an absolute path, relative paths are
+// returned as relative references
+func getSARIFURI(file string) string {
+	if len(file) > 1 && file[1] == ':' {
+		// windows drive letter
+		file = "/" + file
+	} else if !strings.HasPrefix(file, "/") {
+		return (&url.URL{Path: file}).String()
+	}
+	return (&url.URL{Scheme: "file", Path: file}).String()
+}
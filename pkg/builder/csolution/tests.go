@@ -0,0 +1,315 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+const (
+	TestPassed  = "passed"
+	TestFailed  = "failed"
+	TestSkipped = "skipped"
+	TestError   = "error"
+
+	// ExecutablePlaceholder is replaced by the test executable in the runner command
+	ExecutablePlaceholder = "{exe}"
+	DefaultTestTimeout    = 60 * time.Second
+	DefaultJUnitFile      = "test-results.xml"
+)
+
+// TestCase is the result of a single test
+type TestCase struct {
+	Name     string
+	File     string
+	Line     int
+	Status   string
+	Message  string
+	Duration time.Duration
+}
+
+// TestResult is the result of running the test executable of a context
+type TestResult struct {
+	Context    string
+	Executable string
+	Start      time.Time
+	Duration   time.Duration
+	ExitCode   int
+	TimedOut   bool
+	Output     string
+	Cases      []TestCase
+}
+
+var (
+	// unityResultRegex matches the test results of Unity, e.g. 'test/test_main.c:12:test_add:FAIL: Expected 1 Was 2'
+	unityResultRegex = regexp.MustCompile(`^(.+?):(\d+):([^:\s]+):(PASS|FAIL|IGNORE)(?::\s*(.*))?$`)
+)
+
+// parseUnityOutput returns the test cases reported by the Unity framework
+func parseUnityOutput(output string) (cases []TestCase) {
+	for _, line := range strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		matches := unityResultRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(matches[2])
+		test := TestCase{
+			Name:    matches[3],
+			File:    filepath.ToSlash(matches[1]),
+			Line:    lineNumber,
+			Message: matches[5],
+		}
+		switch matches[4] {
+		case "PASS":
+			test.Status = TestPassed
+		case "FAIL":
+			test.Status = TestFailed
+		case "IGNORE":
+			test.Status = TestSkipped
+		}
+		cases = append(cases, test)
+	}
+	return cases
+}
+
+// interpretTestResult derives the test cases from the framework output and the
+// exit code of the test executable
+func interpretTestResult(result *TestResult, timeout time.Duration) {
+	result.Cases = parseUnityOutput(result.Output)
+	name := filepath.Base(result.Executable)
+	failed := false
+	for _, test := range result.Cases {
+		if test.Status == TestFailed {
+			failed = true
+		}
+	}
+	switch {
+	case result.TimedOut:
+		result.Cases = append(result.Cases, TestCase{Name: name, Status: TestError, Duration: result.Duration,
+			Message: "timed out after " + timeout.String()})
+	case result.ExitCode < 0:
+		result.Cases = append(result.Cases, TestCase{Name: name, Status: TestError, Duration: result.Duration,
+			Message: "failed to run " + name})
+	case result.ExitCode != 0 && !failed:
+		result.Cases = append(result.Cases, TestCase{Name: name, Status: TestFailed, Duration: result.Duration,
+			Message: "exited with code " + strconv.Itoa(result.ExitCode)})
+	case len(result.Cases) == 0:
+		status := TestPassed
+		if result.ExitCode != 0 {
+			status = TestFailed
+		}
+		result.Cases = append(result.Cases, TestCase{Name: name, Status: status, Duration: result.Duration})
+	}
+}
+
+// getTestCommand returns the program and the arguments running a test executable,
+// either directly or through the runner command, e.g. an emulator. The runner
+// command is split like a shell command line, so paths containing spaces are
+// quoted. The executable replaces the '{exe}' placeholder of the runner command
+// or is appended to it.
+func getTestCommand(runner string, executable string) (program string, args []string, err error) {
+	fields, err := utils.SplitCommandLine(runner)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(fields) == 0 {
+		return executable, nil, nil
+	}
+	found := false
+	for i, field := range fields {
+		if strings.Contains(field, ExecutablePlaceholder) {
+			fields[i] = strings.ReplaceAll(field, ExecutablePlaceholder, executable)
+			found = true
+		}
+	}
+	if !found {
+		fields = append(fields, executable)
+	}
+	return fields[0], fields[1:], nil
+}
+
+// runTestExecutable runs the test executable of a context with a timeout and
+// captures its output
+func (b CSolutionBuilder) runTestExecutable(ctx string, executable string) (result TestResult) {
+	timeout := b.Options.TestTimeout
+	if timeout <= 0 {
+		timeout = DefaultTestTimeout
+	}
+	result = TestResult{Context: ctx, Executable: executable, Start: time.Now()}
+
+	// the runner command is validated by Test
+	program, args, _ := getTestCommand(b.Options.TestRunner, executable)
+	log.Debug("test command: " + program + " " + strings.Join(args, " "))
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(timeoutCtx, program, args...)
+	cmd.Dir = filepath.Dir(executable)
+	// do not wait for processes started by the test which keep the output open
+	cmd.WaitDelay = time.Second
+	output, err := cmd.CombinedOutput()
+	result.Duration = time.Since(result.Start)
+	result.Output = string(output)
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(timeoutCtx.Err(), context.DeadlineExceeded):
+		result.TimedOut = true
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		result.ExitCode = -1
+		result.Output += err.Error()
+	}
+	interpretTestResult(&result, timeout)
+	return result
+}
+
+// getTestExecutable returns the executable built for a context
+func getTestExecutable(idxFile string, cbuildFile string, context string) string {
+	cbuild, err := utils.ParseCbuildFile(cbuildFile)
+	if err != nil {
+		return ""
+	}
+	for _, output := range cbuild.Build.Output {
+		if output.Type != "elf" && output.Type != "exe" {
+			continue
+		}
+		if filepath.IsAbs(output.File) {
+			return output.File
+		}
+		outDir, err := utils.GetOutDir(idxFile, context)
+		if err != nil {
+			return ""
+		}
+		executable, _ := filepath.Abs(filepath.Join(outDir, output.File))
+		return executable
+	}
+	return ""
+}
+
+// getJUnitFile returns the path of the JUnit report, relative paths are resolved
+// against the output base directory
+func (b CSolutionBuilder) getJUnitFile() string {
+	file := b.Options.JUnitFile
+	if file == "" {
+		file = DefaultJUnitFile
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(b.getOutputBaseDir(), file)
+	}
+	return file
+}
+
+// printTestResult prints the summary and the failed tests of a context
+func (b CSolutionBuilder) printTestResult(result TestResult) (failed int) {
+	counts := make(map[string]int)
+	for _, test := range result.Cases {
+		counts[test.Status]++
+	}
+	failed = counts[TestFailed] + counts[TestError]
+	utils.LogStdMsg(fmt.Sprintf("%s: %d passed, %d failed, %d skipped (%s)", result.Context,
+		counts[TestPassed], failed, counts[TestSkipped], utils.FormatTime(result.Duration)))
+	for _, test := range result.Cases {
+		if test.Status != TestFailed && test.Status != TestError {
+			continue
+		}
+		line := "  FAIL " + test.Name
+		if test.File != "" {
+			line += " (" + test.File + ":" + strconv.Itoa(test.Line) + ")"
+		}
+		if test.Message != "" {
+			line += ": " + test.Message
+		}
+		utils.LogStdMsg(line)
+	}
+	if (failed > 0 || b.Options.Verbose || b.Options.Debug) && result.Output != "" {
+		utils.LogStdMsg(strings.TrimRight(result.Output, "\n"))
+	}
+	return failed
+}
+
+// Test builds the selected contexts, runs their executables and writes the
+// results as JUnit report. With coverage enabled, the contexts are instrumented
+// and a coverage report of the application sources is written too.
+func (b CSolutionBuilder) Test() error {
+	if b.Options.Coverage {
+		program, _ := getGcovCommand(b.Options.GcovTool, "")
+		if _, err := exec.LookPath(program); err != nil {
+			return errutils.New(errutils.ErrGcovNotFound, program)
+		}
+	}
+
+	// check the runner command before building
+	if _, _, err := getTestCommand(b.Options.TestRunner, ""); err != nil {
+		return err
+	}
+
+	if !b.Options.NoBuild {
+		if err := b.Build(); err != nil {
+			return err
+		}
+	}
+
+	idxFile, cbuildFiles, contexts, err := b.getSelectedCbuildFiles()
+	if err != nil {
+		return err
+	}
+
+	var results []TestResult
+	var testedContexts []string
+	failed := 0
+	for _, context := range contexts {
+		executable := getTestExecutable(idxFile, cbuildFiles[context], context)
+		if executable == "" {
+			log.Warn("no executable of context '" + context + "' found, skipping it")
+			continue
+		}
+		if b.Options.Coverage {
+			removeGcovData(b.getContextTmpDir(context))
+		}
+		result := b.runTestExecutable(context, executable)
+		failed += b.printTestResult(result)
+		results = append(results, result)
+		testedContexts = append(testedContexts, context)
+	}
+	if len(results) == 0 {
+		return errutils.New(errutils.ErrNoTestExecutables)
+	}
+
+	junitFile := b.getJUnitFile()
+	if err := writeJUnitReport(junitFile, results); err != nil {
+		return err
+	}
+	log.Info("JUnit report written to " + filepath.ToSlash(junitFile))
+
+	if b.Options.Coverage {
+		coverage, err := b.collectCoverage(testedContexts, cbuildFiles)
+		if err != nil {
+			return err
+		}
+		if err := b.writeCoverageReport(coverage); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return errutils.New(errutils.ErrTestsFailed, failed)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnityOutput(t *testing.T) {
+	assert := assert.New(t)
+	output := "test/test_math.c:12:test_add:PASS\r\n" +
+		"test/test_math.c:20:test_sub:FAIL: Expected 1 Was 2\n" +
+		"test/test_math.c:28:test_mul:IGNORE: not implemented\n" +
+		"test/test_math.c:30:test_div:IGNORE\n" +
+		"\n-----------------------\n4 Tests 1 Failures 2 Ignored\nFAIL\n"
+	assert.Equal([]TestCase{
+		{Name: "test_add", File: "test/test_math.c", Line: 12, Status: TestPassed},
+		{Name: "test_sub", File: "test/test_math.c", Line: 20, Status: TestFailed, Message: "Expected 1 Was 2"},
+		{Name: "test_mul", File: "test/test_math.c", Line: 28, Status: TestSkipped, Message: "not implemented"},
+		{Name: "test_div", File: "test/test_math.c", Line: 30, Status: TestSkipped},
+	}, parseUnityOutput(output))
+}
+
+func TestInterpretTestResult(t *testing.T) {
+	assert := assert.New(t)
+	timeout := 5 * time.Second
+
+	testCases := []struct {
+		name     string
+		result   TestResult
+		expected []TestCase
+	}{
+		{"exit code 0", TestResult{Executable: "/out/test"}, []TestCase{{Name: "test", Status: TestPassed}}},
+		{"exit code 1", TestResult{Executable: "/out/test", ExitCode: 1}, []TestCase{{Name: "test", Status: TestFailed, Message: "exited with code 1"}}},
+		{"timeout", TestResult{Executable: "/out/test", TimedOut: true}, []TestCase{{Name: "test", Status: TestError, Message: "timed out after 5s"}}},
+		{"unity failure", TestResult{Executable: "/out/test", ExitCode: 1, Output: "t.c:3:test_a:FAIL"},
+			[]TestCase{{Name: "test_a", File: "t.c", Line: 3, Status: TestFailed}}},
+		{"unity crash", TestResult{Executable: "/out/test", ExitCode: 139, Output: "t.c:3:test_a:PASS"},
+			[]TestCase{{Name: "test_a", File: "t.c", Line: 3, Status: TestPassed}, {Name: "test", Status: TestFailed, Message: "exited with code 139"}}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			interpretTestResult(&test.result, timeout)
+			assert.Equal(test.expected, test.result.Cases)
+		})
+	}
+}
+
+func TestGetTestCommand(t *testing.T) {
+	assert := assert.New(t)
+	program, args, err := getTestCommand("", "/out/test")
+	assert.Nil(err)
+	assert.Equal("/out/test", program)
+	assert.Empty(args)
+
+	program, args, err = getTestCommand("qemu-system-arm -M mps2-an385 -kernel {exe} -semihosting", "/out/test.elf")
+	assert.Nil(err)
+	assert.Equal("qemu-system-arm", program)
+	assert.Equal([]string{"-M", "mps2-an385", "-kernel", "/out/test.elf", "-semihosting"}, args)
+
+	program, args, err = getTestCommand("FVP_MPS2_Cortex-M3 --application={exe}", "/out/test.elf")
+	assert.Nil(err)
+	assert.Equal("FVP_MPS2_Cortex-M3", program)
+	assert.Equal([]string{"--application=/out/test.elf"}, args)
+
+	program, args, err = getTestCommand("valgrind -q", "/out/test")
+	assert.Nil(err)
+	assert.Equal("valgrind", program)
+	assert.Equal([]string{"-q", "/out/test"}, args)
+
+	program, args, err = getTestCommand(`"/opt/Arm FVP/FVP_MPS2_Cortex-M3" -C 'cpu0.semihosting-cmd_line=test 1' -a {exe}`, "/out/my test.elf")
+	assert.Nil(err)
+	assert.Equal("/opt/Arm FVP/FVP_MPS2_Cortex-M3", program)
+	assert.Equal([]string{"-C", "cpu0.semihosting-cmd_line=test 1", "-a", "/out/my test.elf"}, args)
+
+	_, _, err = getTestCommand(`"/opt/Arm FVP/FVP_MPS2_Cortex-M3 -a {exe}`, "/out/test.elf")
+	assert.EqualError(err, `unclosed quote in command line '"/opt/Arm FVP/FVP_MPS2_Cortex-M3 -a {exe}'`)
+}
+
+func TestRunTests(t *testing.T) {
+	assert := assert.New(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("test executables are shell scripts")
+	}
+
+	solutionDir := t.TempDir()
+	idx := "build-idx:\n  cbuilds:\n" +
+		"    - cbuild: unit.Debug+Native.cbuild.yml\n      project: unit\n      configuration: .Debug+Native\n" +
+		"    - cbuild: app.Debug+Native.cbuild.yml\n      project: app\n      configuration: .Debug+Native\n" +
+		"    - cbuild: lib.Debug+Native.cbuild.yml\n      project: lib\n      configuration: .Debug+Native\n"
+	cbuild := "build:\n  output-dirs:\n    outdir: out/%s\n  output:\n    - type: elf\n      file: %s\n"
+	files := map[string]string{
+		"test.csolution.yml":           "solution:\n",
+		"test.cbuild-idx.yml":          idx,
+		"unit.Debug+Native.cbuild.yml": replaceAll(cbuild, "unit"),
+		"app.Debug+Native.cbuild.yml":  replaceAll(cbuild, "app"),
+		"lib.Debug+Native.cbuild.yml":  "build:\n  output-dirs:\n    outdir: out/lib\n",
+	}
+	for file, content := range files {
+		path := filepath.Join(solutionDir, file)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0600)
+	}
+	writeExecutable := func(name string, script string) {
+		path := filepath.Join(solutionDir, "out", name, name)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		//nolint:gosec // G306: executable permissions required for test binary
+		_ = os.WriteFile(path, []byte("#!/usr/bin/env bash\n"+script), 0755)
+	}
+	writeExecutable("unit", "echo 'test/test_math.c:12:test_add:PASS'\necho 'test/test_math.c:20:test_sub:FAIL: Expected 1 Was 2'\nexit 1\n")
+	writeExecutable("app", "exit 0\n")
+	writeExecutable("slow", "exec sleep 5\n")
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+			Options:   builder.Options{NoBuild: true, TestTimeout: 10 * time.Second},
+		},
+	}
+	junitFile := filepath.Join(solutionDir, DefaultJUnitFile)
+
+	run := func() (string, error) {
+		var buf bytes.Buffer
+		logger := log.StandardLogger().Out
+		defer func() { log.SetOutput(logger) }()
+		log.SetOutput(&buf)
+		err := b.Test()
+		return buf.String(), err
+	}
+
+	t.Run("run tests", func(t *testing.T) {
+		out, err := run()
+		assert.EqualError(err, "1 test(s) failed")
+		assert.Contains(out, "unit.Debug+Native: 1 passed, 1 failed, 0 skipped")
+		assert.Contains(out, "  FAIL test_sub (test/test_math.c:20): Expected 1 Was 2\n")
+		assert.Contains(out, "app.Debug+Native: 1 passed, 0 failed, 0 skipped")
+		assert.Contains(out, "no executable of context 'lib.Debug+Native' found, skipping it")
+
+		data, err := os.ReadFile(junitFile)
+		assert.Nil(err)
+		var report JUnitTestSuites
+		assert.Nil(xml.Unmarshal(data, &report))
+		assert.Equal(3, report.Tests)
+		assert.Equal(1, report.Failures)
+		assert.Equal(2, len(report.Suites))
+		assert.Equal("unit.Debug+Native", report.Suites[0].Name)
+		assert.Equal("test_sub", report.Suites[0].Cases[1].Name)
+		assert.Equal("Expected 1 Was 2", report.Suites[0].Cases[1].Failure.Message)
+		assert.Equal("20", report.Suites[0].Cases[1].Line)
+		assert.Contains(report.Suites[0].SystemOut, "test_add:PASS")
+	})
+
+	t.Run("runner and timeout", func(t *testing.T) {
+		b.Options.Contexts = []string{"app"}
+		b.Options.TestRunner = filepath.Join(solutionDir, "out", "slow", "slow") + " --kernel={exe}"
+		b.Options.TestTimeout = 200 * time.Millisecond
+		b.Options.JUnitFile = filepath.Join(solutionDir, "reports", "app.xml")
+		out, err := run()
+		assert.EqualError(err, "1 test(s) failed")
+		assert.Contains(out, "  FAIL app: timed out after 200ms\n")
+		assert.FileExists(b.Options.JUnitFile)
+	})
+
+	t.Run("no executables", func(t *testing.T) {
+		b.Options.Contexts = []string{"lib"}
+		b.Options.TestRunner = ""
+		_, err := run()
+		assert.EqualError(err, "no test executables found in the selected contexts")
+	})
+}
+
+func replaceAll(format string, value string) string {
+	return fmt.Sprintf(format, value, value)
+}
@@ -0,0 +1,318 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	utils "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+const (
+	VSCodeDir            = ".vscode"
+	CppPropertiesFile    = "c_cpp_properties.json"
+	CppPropertiesVersion = 4
+	LaunchFile           = "launch.json"
+	LaunchVersion        = "0.2.0"
+	DebugConfigVSCode    = "vscode"
+	DefaultGdbPort       = 3333
+)
+
+// DebugConfigs lists the supported targets of the debug configuration generation
+var DebugConfigs = []string{DebugConfigVSCode}
+
+// vscodeCompiler describes the C compiler of a toolchain for the C/C++ extension
+type vscodeCompiler struct {
+	executable       string
+	intelliSenseMode string
+}
+
+var vscodeCompilers = map[string]vscodeCompiler{
+	"AC6":   {executable: "armclang", intelliSenseMode: "clang-arm"},
+	"CLANG": {executable: "clang", intelliSenseMode: "clang-arm"},
+	"GCC":   {executable: "arm-none-eabi-gcc", intelliSenseMode: "gcc-arm"},
+	"IAR":   {executable: "iccarm", intelliSenseMode: "gcc-arm"},
+}
+
+// cortexDebugServers maps debuggers of the cbuild-run.yml to the server types of
+// the cortex-debug extension
+var cortexDebugServers = []struct {
+	debugger   string
+	serverType string
+}{
+	{debugger: "pyocd", serverType: "pyocd"},
+	{debugger: "j-link", serverType: "jlink"},
+	{debugger: "jlink", serverType: "jlink"},
+	{debugger: "st-link", serverType: "stlink"},
+	{debugger: "stlink", serverType: "stlink"},
+	{debugger: "openocd", serverType: "openocd"},
+}
+
+// getWorkspacePath returns the path relative to the workspace folder in the
+// notation of VS Code, paths outside of the workspace stay absolute
+func getWorkspacePath(workspaceDir string, path string) string {
+	rel, err := filepath.Rel(workspaceDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return "${workspaceFolder}/" + filepath.ToSlash(rel)
+}
+
+// getCppConfiguration returns the C/C++ extension configuration of a context
+func (b CSolutionBuilder) getCppConfiguration(workspaceDir string, idxFile string, context string) (map[string]any, error) {
+	configuration := map[string]any{"name": context}
+	if !b.Options.NoDatabase {
+		outDir, err := utils.GetOutDir(idxFile, context)
+		if err != nil {
+			return nil, err
+		}
+		outDir, _ = filepath.Abs(outDir)
+		configuration["compileCommands"] = getWorkspacePath(workspaceDir, filepath.Join(outDir, "compile_commands.json"))
+	}
+
+	toolchainFile := filepath.Join(b.getTmpDir(), utils.GetContextDirName(context), "toolchain.cmake")
+	info, ok := utils.GetToolchainInfo(toolchainFile)
+	if !ok {
+		log.Warn("toolchain of context '" + context + "' not found, skipping compiler settings")
+		return configuration, nil
+	}
+	if compiler, ok := vscodeCompilers[strings.ToUpper(info.Name)]; ok {
+		configuration["compilerPath"] = filepath.ToSlash(filepath.Join(info.Root, compiler.executable+b.InstallConfigs.BinExtn))
+		configuration["intelliSenseMode"] = compiler.intelliSenseMode
+	}
+	return configuration, nil
+}
+
+// generateCppProperties generates or updates the .vscode/c_cpp_properties.json
+// of the solution with one configuration per selected context
+func (b CSolutionBuilder) generateCppProperties(selectedContexts []string) error {
+	workspaceDir, err := filepath.Abs(filepath.Dir(b.InputFile))
+	if err != nil {
+		return err
+	}
+	idxFile, err := b.getIdxFilePath()
+	if err != nil {
+		return err
+	}
+
+	var configurations []map[string]any
+	for _, context := range selectedContexts {
+		configuration, err := b.getCppConfiguration(workspaceDir, idxFile, context)
+		if err != nil {
+			return err
+		}
+		configurations = append(configurations, configuration)
+	}
+
+	file := filepath.Join(workspaceDir, VSCodeDir, CppPropertiesFile)
+	f, err := readVSCodeFile(file)
+	if err != nil {
+		// a file the user broke must not fail the setup
+		log.Warn(err.Error())
+		return nil
+	}
+	updated, err := f.updateConfigurations(CppPropertiesVersion, configurations)
+	if err != nil {
+		return err
+	}
+	if updated {
+		log.Info("Updated " + filepath.ToSlash(file))
+	}
+	return nil
+}
+
+// getCortexDebugServer returns the cortex-debug server type of a debugger,
+// unknown debuggers are connected as external gdb server
+func getCortexDebugServer(debugger string) string {
+	debugger = strings.ToLower(debugger)
+	for _, server := range cortexDebugServers {
+		if strings.Contains(debugger, server.debugger) {
+			return server.serverType
+		}
+	}
+	return "external"
+}
+
+// getDeviceName returns the device name of a '<vendor>::<device>:<pname>' identifier
+func getDeviceName(device string) string {
+	if _, name, found := strings.Cut(device, "::"); found {
+		device = name
+	}
+	device, _, _ = strings.Cut(device, ":")
+	return device
+}
+
+// getGdbPort returns the port of the first gdb server of the cbuild-run.yml
+func getGdbPort(run utils.CbuildRun) int {
+	for _, server := range run.Run.Debugger.Gdbserver {
+		if server.Port != 0 {
+			return server.Port
+		}
+	}
+	return DefaultGdbPort
+}
+
+// getContextProgram returns the elf file of a context listed in its cbuild file,
+// or else the elf file of the cbuild-run.yml generated by the context
+func getContextProgram(idxFile string, cbuildFile string, runDir string, run utils.CbuildRun, context string) string {
+	if cbuild, err := utils.ParseCbuildFile(cbuildFile); err == nil {
+		for _, output := range cbuild.Build.Output {
+			if output.Type != "elf" {
+				continue
+			}
+			if filepath.IsAbs(output.File) {
+				return output.File
+			}
+			if outDir, err := utils.GetOutDir(idxFile, context); err == nil {
+				return filepath.Join(outDir, output.File)
+			}
+		}
+	}
+	for _, output := range run.Run.Output {
+		if output.Type == "elf" && strings.Contains(output.Info, context) {
+			return filepath.Join(runDir, output.File)
+		}
+	}
+	return ""
+}
+
+// getGdbInitCommands returns the gdb commands loading the images and symbols of
+// the cbuild-run.yml at their load offsets, and running to main
+func getGdbInitCommands(workspaceDir string, runDir string, run utils.CbuildRun, program string) (commands []string) {
+	for _, output := range run.Run.Output {
+		file := filepath.Join(runDir, output.File)
+		if strings.Contains(output.Load, "image") {
+			command := "load " + getWorkspacePath(workspaceDir, file)
+			if output.LoadOffset != "" {
+				command += " " + output.LoadOffset
+			}
+			commands = append(commands, command)
+		}
+		if strings.Contains(output.Load, "symbols") && file != program {
+			commands = append(commands, "add-symbol-file "+getWorkspacePath(workspaceDir, file))
+		}
+	}
+	if len(commands) == 0 {
+		commands = append(commands, "load")
+	}
+	return append(commands, "break main")
+}
+
+// getLaunchConfigurations returns the launch configurations of a context for the
+// CMSIS Debugger and the cortex-debug extensions
+func getLaunchConfigurations(workspaceDir string, runFile string, run utils.CbuildRun, context string, program string) []map[string]any {
+	runDir := filepath.Dir(runFile)
+	port := getGdbPort(run)
+	programPath := getWorkspacePath(workspaceDir, program)
+	serverType := getCortexDebugServer(run.Run.Debugger.Name)
+	server := "pyocd"
+	if serverType == "jlink" {
+		server = "JLinkGDBServer"
+	}
+
+	cmsisDebugger := map[string]any{
+		"name":         context + " (CMSIS Debugger)",
+		"type":         "gdbtarget",
+		"request":      "launch",
+		"cwd":          "${workspaceFolder}",
+		"program":      programPath,
+		"gdb":          "arm-none-eabi-gdb",
+		"initCommands": getGdbInitCommands(workspaceDir, runDir, run, program),
+		"target": map[string]any{
+			"server": server,
+			"port":   strconv.Itoa(port),
+		},
+		"cmsis": map[string]any{
+			"cbuildRunFile": getWorkspacePath(workspaceDir, runFile),
+		},
+	}
+
+	cortexDebug := map[string]any{
+		"name":            context + " (cortex-debug)",
+		"type":            "cortex-debug",
+		"request":         "launch",
+		"cwd":             "${workspaceFolder}",
+		"executable":      programPath,
+		"servertype":      serverType,
+		"runToEntryPoint": "main",
+	}
+	if device := getDeviceName(run.Run.Device); device != "" {
+		cortexDebug["device"] = device
+	}
+	if run.Run.Debugger.Protocol != "" {
+		cortexDebug["interface"] = run.Run.Debugger.Protocol
+	}
+	if serverType == "external" {
+		cortexDebug["gdbTarget"] = "localhost:" + strconv.Itoa(port)
+	}
+	return []map[string]any{cmsisDebugger, cortexDebug}
+}
+
+// generateLaunchConfigurations generates or updates the .vscode/launch.json of
+// the solution with debug configurations of the selected contexts derived from
+// the cbuild-run.yml of their target-type
+func (b CSolutionBuilder) generateLaunchConfigurations(selectedContexts []string) error {
+	workspaceDir, err := filepath.Abs(filepath.Dir(b.InputFile))
+	if err != nil {
+		return err
+	}
+	idxFile, err := b.getIdxFilePath()
+	if err != nil {
+		return err
+	}
+	idxData, err := b.ParseCbuildIndexFile(idxFile)
+	if err != nil {
+		return err
+	}
+	if idxData.BuildIdx.CbuildRun == "" {
+		log.Warn("no cbuild-run.yml listed in " + filepath.Base(idxFile) + ", skipping debug configurations")
+		return nil
+	}
+	runFile, _ := filepath.Abs(filepath.Join(filepath.Dir(idxFile), idxData.BuildIdx.CbuildRun))
+	run, err := utils.ParseCbuildRunFile(runFile)
+	if err != nil {
+		return err
+	}
+
+	cbuildFiles, _ := getCbuildFiles(idxFile)
+	var configurations []map[string]any
+	for _, context := range selectedContexts {
+		item, err := utils.ParseContext(context)
+		if err != nil {
+			return err
+		}
+		if run.Run.TargetType != "" && item.TargetType != run.Run.TargetType {
+			log.Warn("no cbuild-run.yml for target-type '" + item.TargetType + "' of context '" + context + "', skipping debug configuration")
+			continue
+		}
+		program := getContextProgram(idxFile, cbuildFiles[context], filepath.Dir(runFile), run, context)
+		if program == "" {
+			log.Warn("no elf file of context '" + context + "' found, skipping debug configuration")
+			continue
+		}
+		configurations = append(configurations, getLaunchConfigurations(workspaceDir, runFile, run, context, program)...)
+	}
+
+	file := filepath.Join(workspaceDir, VSCodeDir, LaunchFile)
+	f, err := readVSCodeFile(file)
+	if err != nil {
+		// a file the user broke must not fail the setup
+		log.Warn(err.Error())
+		return nil
+	}
+	updated, err := f.updateConfigurations(LaunchVersion, configurations)
+	if err != nil {
+		return err
+	}
+	if updated {
+		log.Info("Updated " + filepath.ToSlash(file))
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	builder "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCppProperties(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	idx := "build-idx:\n  cbuilds:\n" +
+		"    - cbuild: out/test.Debug+CM0.cbuild.yml\n      project: test\n      configuration: .Debug+CM0\n" +
+		"    - cbuild: out/test.Release+CM0.cbuild.yml\n      project: test\n      configuration: .Release+CM0\n"
+	cbuild := "build:\n  output-dirs:\n    outdir: %s\n"
+	toolchain := "set(REGISTERED_TOOLCHAIN_ROOT \"/opt/%s/bin\")\nset(REGISTERED_TOOLCHAIN_VERSION \"1.2.3\")\n" +
+		"include(\"${CMSIS_COMPILER_ROOT}/%s.1.2.3.cmake\")\n"
+	files := map[string]string{
+		"test.csolution.yml":                   "solution:\n",
+		"test.cbuild-idx.yml":                  idx,
+		"out/test.Debug+CM0.cbuild.yml":        strings.Replace(cbuild, "%s", "Debug", 1),
+		"out/test.Release+CM0.cbuild.yml":      strings.Replace(cbuild, "%s", "Release", 1),
+		"tmp/test.Debug+CM0/toolchain.cmake":   strings.ReplaceAll(toolchain, "%s", "GCC"),
+		"tmp/test.Release+CM0/toolchain.cmake": strings.ReplaceAll(toolchain, "%s", "AC6"),
+	}
+	for file, content := range files {
+		path := filepath.Join(solutionDir, file)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0600)
+	}
+	contexts := []string{"test.Debug+CM0", "test.Release+CM0"}
+	propertiesFile := filepath.Join(solutionDir, VSCodeDir, CppPropertiesFile)
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+		},
+	}
+
+	readConfigurations := func() (content map[string]any) {
+		data, err := os.ReadFile(propertiesFile)
+		assert.Nil(err)
+		assert.Nil(json.Unmarshal(data, &content))
+		return content
+	}
+
+	t.Run("generate configurations", func(t *testing.T) {
+		assert.Nil(b.generateCppProperties(contexts))
+		content := readConfigurations()
+		assert.Equal(float64(CppPropertiesVersion), content["version"])
+		assert.Equal([]any{
+			map[string]any{
+				"name":             "test.Debug+CM0",
+				"compileCommands":  "${workspaceFolder}/out/Debug/compile_commands.json",
+				"compilerPath":     "/opt/GCC/bin/arm-none-eabi-gcc",
+				"intelliSenseMode": "gcc-arm",
+				"generatedBy":      "cbuild",
+			},
+			map[string]any{
+				"name":             "test.Release+CM0",
+				"compileCommands":  "${workspaceFolder}/out/Release/compile_commands.json",
+				"compilerPath":     "/opt/AC6/bin/armclang",
+				"intelliSenseMode": "clang-arm",
+				"generatedBy":      "cbuild",
+			},
+		}, content["configurations"])
+	})
+
+	t.Run("unchanged file is kept", func(t *testing.T) {
+		data, _ := os.ReadFile(propertiesFile)
+		data = append([]byte("// generated by cbuild setup\n"), data...)
+		assert.Nil(os.WriteFile(propertiesFile, data, 0600))
+		assert.Nil(b.generateCppProperties(contexts))
+		updated, _ := os.ReadFile(propertiesFile)
+		assert.Equal(string(data), string(updated))
+	})
+
+	t.Run("refresh generated entries", func(t *testing.T) {
+		data, _ := os.ReadFile(propertiesFile)
+		edited := strings.Replace(string(data), `"compilerPath": "/opt/GCC/bin/arm-none-eabi-gcc",`,
+			`"compilerPath": "/old/gcc",
+      "defines": ["USER"],`, 1)
+		assert.NotEqual(string(data), edited)
+		assert.Nil(os.WriteFile(propertiesFile, []byte(edited), 0600))
+		assert.Nil(b.generateCppProperties(contexts))
+		updated, _ := os.ReadFile(propertiesFile)
+		assert.True(strings.HasPrefix(string(updated), "// generated by cbuild setup\n"))
+		content := make(map[string]any)
+		assert.Nil(json.Unmarshal(stripJSONC(updated), &content))
+		debug := content["configurations"].([]any)[0]
+		assert.Equal(map[string]any{
+			"name":             "test.Debug+CM0",
+			"compileCommands":  "${workspaceFolder}/out/Debug/compile_commands.json",
+			"compilerPath":     "/opt/GCC/bin/arm-none-eabi-gcc",
+			"intelliSenseMode": "gcc-arm",
+			"generatedBy":      "cbuild",
+			"defines":          []any{"USER"},
+		}, debug)
+		// the keys keep their order
+		assert.Less(strings.Index(string(updated), `"compilerPath"`), strings.Index(string(updated), `"defines"`))
+		assert.Less(strings.Index(string(updated), `"defines"`), strings.Index(string(updated), `"intelliSenseMode"`))
+	})
+
+	t.Run("preserve user entries", func(t *testing.T) {
+		user := `{
+  "env": {"myPath": "/home/dev"},
+  "configurations": [
+    {"name": "host", "compilerPath": "/usr/bin/gcc"},
+    {"name": "test.Debug+CM0", "compilerPath": "/old/gcc", "defines": ["USER"]}
+  ],
+  "version": 4
+}`
+		assert.Nil(os.WriteFile(propertiesFile, []byte(user), 0600))
+		assert.Nil(b.generateCppProperties(contexts[:1]))
+		content := readConfigurations()
+		assert.Equal(map[string]any{"myPath": "/home/dev"}, content["env"])
+		assert.Equal([]any{
+			map[string]any{"name": "host", "compilerPath": "/usr/bin/gcc"},
+			map[string]any{
+				"name":             "test.Debug+CM0",
+				"compileCommands":  "${workspaceFolder}/out/Debug/compile_commands.json",
+				"compilerPath":     "/old/gcc",
+				"intelliSenseMode": "gcc-arm",
+				"defines":          []any{"USER"},
+			},
+		}, content["configurations"])
+	})
+
+	t.Run("file with comments and trailing commas", func(t *testing.T) {
+		jsonc := "{\n  // user settings\n  \"env\": {\"url\": \"http://example.com\", /* proxy */},\n  \"configurations\": [],\n}\n"
+		assert.Nil(os.WriteFile(propertiesFile, []byte(jsonc), 0600))
+		assert.Nil(b.generateCppProperties(contexts))
+		f, err := readVSCodeFile(propertiesFile)
+		assert.Nil(err)
+		configurations, _ := f.get("configurations")
+		assert.Len(configurations, len(contexts))
+		version, _ := f.get("version")
+		assert.Equal(json.Number("4"), version)
+
+		// the comments and the other settings are kept
+		data, _ := os.ReadFile(propertiesFile)
+		assert.True(strings.HasPrefix(string(data),
+			"{\n  // user settings\n  \"env\": {\"url\": \"http://example.com\", /* proxy */},\n  \"configurations\": [\n    {\n"))
+		assert.True(strings.HasSuffix(string(data), "\n  ],\n  \"version\": 4,\n}\n"))
+	})
+
+	t.Run("invalid file is kept", func(t *testing.T) {
+		invalid := "{\n  \"configurations\": [\n}"
+		assert.Nil(os.WriteFile(propertiesFile, []byte(invalid), 0600))
+		assert.Nil(b.generateCppProperties(contexts))
+		data, _ := os.ReadFile(propertiesFile)
+		assert.Equal(invalid, string(data))
+		_, err := readVSCodeFile(propertiesFile)
+		assert.ErrorContains(err, "unable to update '"+filepath.ToSlash(propertiesFile)+"', keeping the file unchanged")
+	})
+
+	t.Run("missing toolchain", func(t *testing.T) {
+		_ = os.Remove(propertiesFile)
+		_ = os.Remove(filepath.Join(solutionDir, "tmp", "test.Debug+CM0", "toolchain.cmake"))
+		b.Options.NoDatabase = true
+		defer func() { b.Options.NoDatabase = false }()
+		assert.Nil(b.generateCppProperties(contexts[:1]))
+		content := readConfigurations()
+		assert.Equal([]any{map[string]any{"name": "test.Debug+CM0", "generatedBy": "cbuild"}}, content["configurations"])
+	})
+}
+
+func TestGenerateLaunchConfigurations(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := t.TempDir()
+	idx := "build-idx:\n  cbuild-run: out/test+CM0.cbuild-run.yml\n  cbuilds:\n" +
+		"    - cbuild: out/test.Debug+CM0.cbuild.yml\n      project: test\n      configuration: .Debug+CM0\n" +
+		"    - cbuild: out/boot.Debug+CM0.cbuild.yml\n      project: boot\n      configuration: .Debug+CM0\n" +
+		"    - cbuild: out/test.Debug+CM3.cbuild.yml\n      project: test\n      configuration: .Debug+CM3\n"
+	run := `cbuild-run:
+  target-type: CM0
+  device: ARM::ARMCM0
+  output:
+    - file: boot/boot.axf
+      info: generate by boot.Debug+CM0
+      type: elf
+      load: symbols
+    - file: boot/boot.hex
+      type: hex
+      load: image
+      load-offset: 0x10000
+    - file: test/Debug/test.axf
+      info: generate by test.Debug+CM0
+      type: elf
+      load: image+symbols
+  debugger:
+    name: CMSIS-DAP@pyOCD
+    protocol: swd
+    gdbserver:
+      - port: 3334
+`
+	files := map[string]string{
+		"test.csolution.yml":            "solution:\n",
+		"test.cbuild-idx.yml":           idx,
+		"out/test+CM0.cbuild-run.yml":   run,
+		"out/test.Debug+CM0.cbuild.yml": "build:\n  output-dirs:\n    outdir: test/Debug\n  output:\n    - type: elf\n      file: test.axf\n",
+		"out/boot.Debug+CM0.cbuild.yml": "build:\n  output-dirs:\n    outdir: boot\n",
+		"out/test.Debug+CM3.cbuild.yml": "build:\n  output-dirs:\n    outdir: test/CM3\n",
+	}
+	for file, content := range files {
+		path := filepath.Join(solutionDir, file)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0600)
+	}
+	launchFile := filepath.Join(solutionDir, VSCodeDir, LaunchFile)
+
+	b := CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			InputFile: filepath.Join(solutionDir, "test.csolution.yml"),
+		},
+	}
+
+	t.Run("generate launch configurations", func(t *testing.T) {
+		assert.Nil(os.MkdirAll(filepath.Dir(launchFile), 0755))
+		assert.Nil(os.WriteFile(launchFile, []byte(`{"version": "0.2.0", "configurations": [{"name": "user", "type": "node"}]}`), 0600))
+		assert.Nil(b.generateLaunchConfigurations([]string{"test.Debug+CM0", "boot.Debug+CM0", "test.Debug+CM3"}))
+
+		data, err := os.ReadFile(launchFile)
+		assert.Nil(err)
+		var content map[string]any
+		assert.Nil(json.Unmarshal(data, &content))
+		assert.Equal("0.2.0", content["version"])
+		configurations := content["configurations"].([]any)
+		assert.Equal(5, len(configurations))
+		assert.Equal(map[string]any{"name": "user", "type": "node"}, configurations[0])
+
+		initCommands := []any{
+			"add-symbol-file ${workspaceFolder}/out/boot/boot.axf",
+			"load ${workspaceFolder}/out/boot/boot.hex 0x10000",
+			"load ${workspaceFolder}/out/test/Debug/test.axf",
+			"break main",
+		}
+		assert.Equal(map[string]any{
+			"name":         "test.Debug+CM0 (CMSIS Debugger)",
+			"type":         "gdbtarget",
+			"request":      "launch",
+			"cwd":          "${workspaceFolder}",
+			"program":      "${workspaceFolder}/out/test/Debug/test.axf",
+			"gdb":          "arm-none-eabi-gdb",
+			"initCommands": initCommands,
+			"target":       map[string]any{"server": "pyocd", "port": "3334"},
+			"cmsis":        map[string]any{"cbuildRunFile": "${workspaceFolder}/out/test+CM0.cbuild-run.yml"},
+			"generatedBy":  "cbuild",
+		}, configurations[1])
+		assert.Equal(map[string]any{
+			"name":            "test.Debug+CM0 (cortex-debug)",
+			"type":            "cortex-debug",
+			"request":         "launch",
+			"cwd":             "${workspaceFolder}",
+			"executable":      "${workspaceFolder}/out/test/Debug/test.axf",
+			"servertype":      "pyocd",
+			"device":          "ARMCM0",
+			"interface":       "swd",
+			"runToEntryPoint": "main",
+			"generatedBy":     "cbuild",
+		}, configurations[2])
+
+		// the elf file of boot is taken from the cbuild-run.yml
+		boot := configurations[3].(map[string]any)
+		assert.Equal("boot.Debug+CM0 (CMSIS Debugger)", boot["name"])
+		assert.Equal("${workspaceFolder}/out/boot/boot.axf", boot["program"])
+		assert.Equal([]any{
+			"load ${workspaceFolder}/out/boot/boot.hex 0x10000",
+			"load ${workspaceFolder}/out/test/Debug/test.axf",
+			"add-symbol-file ${workspaceFolder}/out/test/Debug/test.axf",
+			"break main",
+		}, boot["initCommands"])
+	})
+
+	t.Run("debugger servers", func(t *testing.T) {
+		assert.Equal("pyocd", getCortexDebugServer("CMSIS-DAP@pyOCD"))
+		assert.Equal("jlink", getCortexDebugServer("J-Link Server"))
+		assert.Equal("stlink", getCortexDebugServer("ST-Link"))
+		assert.Equal("external", getCortexDebugServer("Arm-Debugger"))
+		assert.Equal("ARMCM0", getDeviceName("ARM::ARMCM0"))
+		assert.Equal("STM32H745XIHx", getDeviceName("STMicroelectronics::STM32H745XIHx:CM7"))
+	})
+
+	t.Run("missing cbuild-run", func(t *testing.T) {
+		assert.Nil(os.WriteFile(filepath.Join(solutionDir, "test.cbuild-idx.yml"), []byte(strings.Replace(idx, "  cbuild-run: out/test+CM0.cbuild-run.yml\n", "", 1)), 0600))
+		assert.Nil(os.Remove(launchFile))
+		assert.Nil(b.generateLaunchConfigurations([]string{"test.Debug+CM0"}))
+		assert.NoFileExists(launchFile)
+	})
+}
+
+func TestStripJSONC(t *testing.T) {
+	assert := assert.New(t)
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"{\"a\": 1} // comment", "{\"a\": 1}           "},
+		{"{/* block */\"a\": [1, 2,],}", "{           \"a\": [1, 2 ] }"},
+		{"{\"a\": \"// not a comment, }\"}", "{\"a\": \"// not a comment, }\"}"},
+		{"{\"a\": \"quote \\\" /* kept */\"}", "{\"a\": \"quote \\\" /* kept */\"}"},
+		{"[1,\n  // last\n]", "[1 \n         \n]"},
+		{"/* multi\nline */[]", "        \n       []"},
+	}
+	for _, test := range testCases {
+		assert.Equal(test.expected, string(stripJSONC([]byte(test.input))))
+	}
+}
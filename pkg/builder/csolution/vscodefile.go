@@ -0,0 +1,443 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package csolution
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+)
+
+// GeneratedKey marks the entries of the .vscode files generated by cbuild, they
+// are refreshed on every setup. Entries without the key belong to the user.
+const (
+	GeneratedKey   = "generatedBy"
+	GeneratedValue = "cbuild"
+)
+
+// jsonObject is a JSON object keeping the order of its keys, so that rewritten
+// entries keep the layout of the user
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func newJSONObject() *jsonObject {
+	return &jsonObject{values: make(map[string]any)}
+}
+
+// toJSONObject converts a generated map into an object with the 'name' first and
+// the other keys sorted
+func toJSONObject(content map[string]any) *jsonObject {
+	object := newJSONObject()
+	var keys []string
+	for key := range content {
+		if key != "name" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if name, ok := content["name"]; ok {
+		object.set("name", name)
+	}
+	for _, key := range keys {
+		value := content[key]
+		if nested, ok := value.(map[string]any); ok {
+			value = toJSONObject(nested)
+		}
+		object.set(key, value)
+	}
+	return object
+}
+
+func (o *jsonObject) get(key string) (any, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// set replaces the value of a key, new keys are appended
+func (o *jsonObject) set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		data, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+		buf.WriteByte(':')
+		if data, err = json.Marshal(o.values[key]); err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeJSONValue decodes the next value, objects become *jsonObject
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := newJSONObject()
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := token.(string)
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			object.set(key, value)
+		}
+		_, err = dec.Token()
+		return object, err
+	case json.Delim('['):
+		list := []any{}
+		for dec.More() {
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = dec.Token()
+		return list, err
+	}
+	return token, nil
+}
+
+// jsonEqual compares two values by their JSON content, regardless of the order
+// of the keys
+func jsonEqual(a any, b any) bool {
+	var contentA, contentB any
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	if json.Unmarshal(dataA, &contentA) != nil || json.Unmarshal(dataB, &contentB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(contentA, contentB)
+}
+
+// stripJSONC blanks the comments and trailing commas VS Code accepts in its JSON
+// files, strings are kept unchanged. The offsets of the content are preserved.
+func stripJSONC(data []byte) []byte {
+	result := bytes.Clone(data)
+	blank := func(from int, to int) {
+		for i := from; i < to && i < len(result); i++ {
+			if result[i] != '\n' && result[i] != '\r' {
+				result[i] = ' '
+			}
+		}
+	}
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				end = len(data) - i
+			}
+			blank(i, i+end)
+			i += end - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				end = len(data)
+			} else {
+				end += i + 4
+			}
+			blank(i, end)
+			i = end - 1
+		case c == '}' || c == ']':
+			// drop the comma preceding the closing bracket
+			trimmed := bytes.TrimRight(result[:i], " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				result[len(trimmed)-1] = ' '
+			}
+		}
+	}
+	return result
+}
+
+// vscodeMember is a top-level member of a .vscode file with the range of its
+// value in the file
+type vscodeMember struct {
+	value      any
+	start, end int
+}
+
+// vscodeFile is a JSON file of the .vscode folder. Updates only replace the
+// values of the changed top-level members in the text of the file, so that the
+// comments and the layout of the other settings are kept.
+type vscodeFile struct {
+	path    string
+	data    []byte
+	members map[string]vscodeMember
+	// end of the value of the last member and indentation of the members
+	lastEnd int
+	indent  string
+	edits   map[string]any
+}
+
+// readVSCodeFile reads a JSON file of the .vscode folder, comments and trailing
+// commas are accepted. A missing file yields an empty content.
+func readVSCodeFile(file string) (*vscodeFile, error) {
+	f := &vscodeFile{path: file, members: make(map[string]vscodeMember), edits: make(map[string]any)}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return nil, err
+	}
+	if err := f.parse(data); err != nil {
+		return nil, errutils.New(errutils.ErrInvalidVSCodeFile, filepath.ToSlash(file), err)
+	}
+	f.data = data
+	return f, nil
+}
+
+func (f *vscodeFile) parse(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(stripJSONC(data)))
+	dec.UseNumber()
+	if token, err := dec.Token(); err != nil {
+		return err
+	} else if token != json.Delim('{') {
+		return errors.New("the content is no JSON object")
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		end := int(dec.InputOffset())
+		start := end - len(raw)
+		rawDec := json.NewDecoder(bytes.NewReader(raw))
+		rawDec.UseNumber()
+		value, err := decodeJSONValue(rawDec)
+		if err != nil {
+			return err
+		}
+		f.members[key] = vscodeMember{value: value, start: start, end: end}
+		if f.indent == "" {
+			f.indent = getLineIndent(data, start)
+		}
+		f.lastEnd = end
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// getLineIndent returns the leading white space of the line at an offset
+func getLineIndent(data []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	line := data[lineStart:offset]
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// get returns the value of a top-level member
+func (f *vscodeFile) get(key string) (any, bool) {
+	if value, ok := f.edits[key]; ok {
+		return value, true
+	}
+	member, ok := f.members[key]
+	return member.value, ok
+}
+
+// set changes the value of a top-level member, unchanged values are ignored
+func (f *vscodeFile) set(key string, value any) {
+	if member, ok := f.members[key]; ok && jsonEqual(member.value, value) {
+		delete(f.edits, key)
+		return
+	}
+	f.edits[key] = value
+}
+
+// changed reports whether the file needs to be written
+func (f *vscodeFile) changed() bool {
+	return f.data == nil || len(f.edits) > 0
+}
+
+// write writes the file with the changed members
+func (f *vscodeFile) write() error {
+	indent := f.indent
+	if indent == "" {
+		indent = "  "
+	}
+	var keys []string
+	for key := range f.edits {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var data []byte
+	if len(f.members) == 0 {
+		// new or empty file
+		object := newJSONObject()
+		for _, key := range keys {
+			object.set(key, f.edits[key])
+		}
+		content, err := json.MarshalIndent(object, "", indent)
+		if err != nil {
+			return err
+		}
+		data = append(content, '\n')
+	} else {
+		type edit struct {
+			start, end int
+			text       string
+		}
+		var edits []edit
+		var inserted strings.Builder
+		for _, key := range keys {
+			member, ok := f.members[key]
+			lineIndent := indent
+			if ok {
+				lineIndent = getLineIndent(f.data, member.start)
+			}
+			value, err := json.MarshalIndent(f.edits[key], lineIndent, indent)
+			if err != nil {
+				return err
+			}
+			if ok {
+				edits = append(edits, edit{start: member.start, end: member.end, text: string(value)})
+				continue
+			}
+			name, _ := json.Marshal(key)
+			inserted.WriteString(",\n" + indent + string(name) + ": " + string(value))
+		}
+		if inserted.Len() > 0 {
+			edits = append(edits, edit{start: f.lastEnd, end: f.lastEnd, text: inserted.String()})
+		}
+		sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+		data = bytes.Clone(f.data)
+		for _, e := range edits {
+			data = append(data[:e.start], append([]byte(e.text), data[e.end:]...)...)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, 0600)
+}
+
+// updateNamedEntries adds the generated entries to a JSON list, entries are
+// identified by their 'name'. Entries marked as generated are refreshed with the
+// generated values, other existing entries belong to the user and only their
+// missing keys are filled in. Entries with other names are preserved, new
+// entries are appended.
+func updateNamedEntries(list []any, entries []map[string]any) []any {
+	for _, content := range entries {
+		entry := toJSONObject(content)
+		entry.set(GeneratedKey, GeneratedValue)
+		found := false
+		for _, item := range list {
+			existing, ok := item.(*jsonObject)
+			if !ok {
+				continue
+			}
+			if name, _ := existing.get("name"); name != content["name"] {
+				continue
+			}
+			marker, _ := existing.get(GeneratedKey)
+			generated := marker == GeneratedValue
+			for _, key := range entry.keys {
+				if _, ok := existing.get(key); generated || (!ok && key != GeneratedKey) {
+					existing.set(key, entry.values[key])
+				}
+			}
+			found = true
+			break
+		}
+		if !found {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// updateConfigurations updates the 'configurations' list of the file with the
+// generated entries and writes the file, if anything changed
+func (f *vscodeFile) updateConfigurations(version any, entries []map[string]any) (updated bool, err error) {
+	value, _ := f.get("configurations")
+	list, _ := value.([]any)
+	list = updateNamedEntries(cloneJSONList(list), entries)
+	f.set("configurations", list)
+	if _, ok := f.get("version"); !ok {
+		f.set("version", version)
+	}
+	if !f.changed() {
+		return false, nil
+	}
+	return true, f.write()
+}
+
+// cloneJSONList copies a decoded list, so that the decoded content stays unchanged
+func cloneJSONList(list []any) []any {
+	result := make([]any, 0, len(list))
+	for _, item := range list {
+		result = append(result, cloneJSONValue(item))
+	}
+	return result
+}
+
+func cloneJSONValue(value any) any {
+	switch v := value.(type) {
+	case *jsonObject:
+		object := newJSONObject()
+		for _, key := range v.keys {
+			object.set(key, cloneJSONValue(v.values[key]))
+		}
+		return object
+	case []any:
+		return cloneJSONList(v)
+	}
+	return value
+}
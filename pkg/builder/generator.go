@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package builder
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/hashicorp/go-version"
+)
+
+// NinjaVersion is the first ninja version supporting the '--quiet' option
+const NinjaVersion = "1.11.1"
+
+// Supported CMake generators
+const (
+	GeneratorNinja            = "Ninja"
+	GeneratorNinjaMultiConfig = "Ninja Multi-Config"
+	GeneratorUnixMakefiles    = "Unix Makefiles"
+)
+
+// IsNinjaGenerator reports whether the generator drives the build with ninja
+func IsNinjaGenerator(generator string) bool {
+	return generator == GeneratorNinja || generator == GeneratorNinjaMultiConfig
+}
+
+// ResolveGenerator returns the CMake generator to be used and checks the
+// presence of its build tool. When ninja is not installed the default
+// 'Ninja' generator falls back to 'Unix Makefiles'.
+// Other generators are passed to CMake without further checks.
+func (b BuilderParams) ResolveGenerator(vars InternalVars) (string, error) {
+	generator := b.Options.Generator
+	if generator == "" {
+		generator = GeneratorNinja
+	}
+
+	switch generator {
+	case GeneratorNinja, GeneratorNinjaMultiConfig:
+		if vars.NinjaBin != "" {
+			return generator, nil
+		}
+		if generator == GeneratorNinja && vars.MakeBin != "" {
+			log.Warn(fmt.Sprintf(errutils.WarnGeneratorFallback, GeneratorUnixMakefiles))
+			return GeneratorUnixMakefiles, nil
+		}
+		return "", errutils.New(errutils.ErrBinaryNotFound, "ninja", "for generator '"+generator+"'")
+	case GeneratorUnixMakefiles:
+		if vars.MakeBin == "" {
+			return "", errutils.New(errutils.ErrBinaryNotFound, "make", "for generator '"+generator+"'")
+		}
+	}
+	return generator, nil
+}
+
+// GetCachedGenerator returns the generator the build directory was configured
+// with, or an empty string if it is not configured
+func GetCachedGenerator(buildDir string) string {
+	file, err := os.Open(filepath.Join(buildDir, "CMakeCache.txt"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if generator, ok := strings.CutPrefix(scanner.Text(), "CMAKE_GENERATOR:INTERNAL="); ok {
+			return generator
+		}
+	}
+	return ""
+}
+
+// CleanChangedGenerator deletes the CMake cache of a build directory configured
+// with another generator, as CMake refuses to switch the generator of a build
+// directory. It returns true if the cache was deleted.
+func CleanChangedGenerator(buildDir string, generator string) bool {
+	cached := GetCachedGenerator(buildDir)
+	if cached == "" || cached == generator {
+		return false
+	}
+	log.Warn("'" + filepath.ToSlash(buildDir) + "' was configured with generator '" + cached +
+		"', reconfiguring it with generator '" + generator + "'")
+	for _, path := range []string{"CMakeCache.txt", "CMakeFiles"} {
+		if err := os.RemoveAll(filepath.Join(buildDir, path)); err != nil {
+			log.Warn(err.Error())
+		}
+	}
+	return true
+}
+
+// GetBuildToolArgs returns the 'cmake --build' arguments controlling
+// the output of the generator specific build tool
+func GetBuildToolArgs(generator string, verbose bool, quiet bool) []string {
+	if verbose {
+		return []string{"--verbose"}
+	}
+	if quiet {
+		switch generator {
+		case GeneratorNinja, GeneratorNinjaMultiConfig:
+			return []string{"--", "--quiet"}
+		case GeneratorUnixMakefiles:
+			return []string{"--", "--no-print-directory"}
+		}
+	}
+	return nil
+}
+
+// GetBuildToolOptions returns the 'cmake --build' arguments controlling the
+// output of the build tool. The quiet option of ninja requires NinjaVersion,
+// older versions build without it.
+func (b BuilderParams) GetBuildToolOptions(quiet bool) ([]string, error) {
+	verbose := b.Options.Debug || b.Options.Verbose
+	if IsNinjaGenerator(b.Options.Generator) && !verbose && quiet {
+		isVersionGreaterorEqual, err := b.ValidateNinjaVersion(NinjaVersion)
+		if err != nil {
+			return nil, err
+		}
+		if !isVersionGreaterorEqual {
+			log.Warn(errutils.WarnNinjaVersion)
+			return nil, nil
+		}
+	}
+	return GetBuildToolArgs(b.Options.Generator, verbose, quiet), nil
+}
+
+func (b BuilderParams) ValidateNinjaVersion(refVersion string) (bool, error) {
+	// Fetch installed version of ninja
+	version, err := b.GetNinjaVersion()
+	if err != nil {
+		return false, err
+	}
+
+	// Compare with the reference version
+	result, err := CompareVersions(version, refVersion)
+	if err != nil {
+		return false, err
+	}
+
+	// Installed ninja version is lesser
+	if result == -1 {
+		return false, nil
+	}
+
+	// Installed version is greater or equal
+	return true, nil
+}
+
+// Retrieves ninja version
+func (b BuilderParams) GetNinjaVersion() (string, error) {
+	versionStr, err := b.Runner.ExecuteCommand("ninja", true, "--version")
+	if err != nil {
+		return "", errutils.New(errutils.ErrBinaryNotFound, "ninja", "")
+	}
+
+	re := regexp.MustCompile(`^[\d]+.[\d+]+.[\d+]`)
+	version := re.FindString(versionStr)
+	if version == "" {
+		return "", errutils.New(errutils.ErrNinjaVersionNotFound)
+	}
+	return version, nil
+}
+
+// CompareVersions compares this version to another version. This
+// returns -1, 0, or 1 if this version is smaller, equal,
+// or larger than the other version, respectively
+// or error when invalid input
+func CompareVersions(v1, v2 string) (int, error) {
+	version1, err := version.NewSemver(v1)
+	if err != nil {
+		return 0, err
+	}
+	version2, err := version.NewSemver(v2)
+	if err != nil {
+		return 0, err
+	}
+
+	return version1.Compare(version2), nil
+}
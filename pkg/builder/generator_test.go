@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveGenerator(t *testing.T) {
+	assert := assert.New(t)
+	vars := InternalVars{NinjaBin: "/usr/bin/ninja", MakeBin: "/usr/bin/make"}
+
+	t.Run("default generator", func(t *testing.T) {
+		b := BuilderParams{}
+		generator, err := b.ResolveGenerator(vars)
+		assert.Nil(err)
+		assert.Equal(GeneratorNinja, generator)
+	})
+
+	t.Run("fallback to makefiles", func(t *testing.T) {
+		b := BuilderParams{Options: Options{Generator: GeneratorNinja}}
+		generator, err := b.ResolveGenerator(InternalVars{MakeBin: "/usr/bin/make"})
+		assert.Nil(err)
+		assert.Equal(GeneratorUnixMakefiles, generator)
+	})
+
+	t.Run("ninja and make missing", func(t *testing.T) {
+		b := BuilderParams{}
+		_, err := b.ResolveGenerator(InternalVars{})
+		assert.EqualError(err, "ninja not found for generator 'Ninja'")
+	})
+
+	t.Run("multi-config requires ninja", func(t *testing.T) {
+		b := BuilderParams{Options: Options{Generator: GeneratorNinjaMultiConfig}}
+		_, err := b.ResolveGenerator(InternalVars{MakeBin: "/usr/bin/make"})
+		assert.EqualError(err, "ninja not found for generator 'Ninja Multi-Config'")
+		generator, err := b.ResolveGenerator(vars)
+		assert.Nil(err)
+		assert.Equal(GeneratorNinjaMultiConfig, generator)
+	})
+
+	t.Run("makefiles require make", func(t *testing.T) {
+		b := BuilderParams{Options: Options{Generator: GeneratorUnixMakefiles}}
+		_, err := b.ResolveGenerator(InternalVars{NinjaBin: "/usr/bin/ninja"})
+		assert.EqualError(err, "make not found for generator 'Unix Makefiles'")
+	})
+
+	t.Run("other generator", func(t *testing.T) {
+		b := BuilderParams{Options: Options{Generator: "MinGW Makefiles"}}
+		generator, err := b.ResolveGenerator(InternalVars{})
+		assert.Nil(err)
+		assert.Equal("MinGW Makefiles", generator)
+	})
+}
+
+func TestGetBuildToolArgs(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal([]string{"--verbose"}, GetBuildToolArgs(GeneratorUnixMakefiles, true, true))
+	assert.Equal([]string{"--", "--quiet"}, GetBuildToolArgs(GeneratorNinja, false, true))
+	assert.Equal([]string{"--", "--quiet"}, GetBuildToolArgs(GeneratorNinjaMultiConfig, false, true))
+	assert.Equal([]string{"--", "--no-print-directory"}, GetBuildToolArgs(GeneratorUnixMakefiles, false, true))
+	assert.Nil(GetBuildToolArgs(GeneratorNinja, false, false))
+	assert.Nil(GetBuildToolArgs("MinGW Makefiles", false, true))
+}
+
+type ninjaVersionRunner struct {
+	version string
+}
+
+func (r ninjaVersionRunner) ExecuteCommand(program string, _ bool, args ...string) (string, error) {
+	if program == "ninja" && len(args) == 1 && args[0] == "--version" {
+		return r.version, nil
+	}
+	return "", nil
+}
+
+func TestGetBuildToolOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("supported ninja version", func(t *testing.T) {
+		b := BuilderParams{Runner: ninjaVersionRunner{version: NinjaVersion}, Options: Options{Generator: GeneratorNinja}}
+		args, err := b.GetBuildToolOptions(true)
+		assert.Nil(err)
+		assert.Equal([]string{"--", "--quiet"}, args)
+	})
+
+	t.Run("older ninja version", func(t *testing.T) {
+		b := BuilderParams{Runner: ninjaVersionRunner{version: "1.10.2"}, Options: Options{Generator: GeneratorNinja}}
+		args, err := b.GetBuildToolOptions(true)
+		assert.Nil(err)
+		assert.Nil(args)
+	})
+
+	t.Run("unknown ninja version", func(t *testing.T) {
+		b := BuilderParams{Runner: ninjaVersionRunner{}, Options: Options{Generator: GeneratorNinja}}
+		_, err := b.GetBuildToolOptions(true)
+		assert.Error(err)
+	})
+
+	t.Run("makefiles skip the version check", func(t *testing.T) {
+		b := BuilderParams{Runner: ninjaVersionRunner{}, Options: Options{Generator: GeneratorUnixMakefiles}}
+		args, err := b.GetBuildToolOptions(true)
+		assert.Nil(err)
+		assert.Equal([]string{"--", "--no-print-directory"}, args)
+	})
+}
+
+func TestCleanChangedGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("not configured", func(t *testing.T) {
+		buildDir := t.TempDir()
+		assert.Empty(GetCachedGenerator(buildDir))
+		assert.False(CleanChangedGenerator(buildDir, GeneratorNinja))
+	})
+
+	t.Run("same generator", func(t *testing.T) {
+		buildDir := t.TempDir()
+		cache := filepath.Join(buildDir, "CMakeCache.txt")
+		assert.Nil(os.WriteFile(cache, []byte("CMAKE_GENERATOR:INTERNAL=Ninja\n"), 0600))
+		assert.Equal(GeneratorNinja, GetCachedGenerator(buildDir))
+		assert.False(CleanChangedGenerator(buildDir, GeneratorNinja))
+		assert.FileExists(cache)
+	})
+
+	t.Run("changed generator", func(t *testing.T) {
+		buildDir := t.TempDir()
+		cache := filepath.Join(buildDir, "CMakeCache.txt")
+		cmakeFiles := filepath.Join(buildDir, "CMakeFiles")
+		assert.Nil(os.WriteFile(cache, []byte("CMAKE_HOME_DIRECTORY:INTERNAL=/tmp\nCMAKE_GENERATOR:INTERNAL=Ninja\n"), 0600))
+		assert.Nil(os.MkdirAll(cmakeFiles, 0755))
+		assert.True(CleanChangedGenerator(buildDir, GeneratorUnixMakefiles))
+		assert.NoFileExists(cache)
+		assert.NoDirExists(cmakeFiles)
+	})
+}
@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2023-2025 Arm Limited. All rights reserved.
+ * Copyright (c) 2023-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
@@ -24,6 +25,7 @@ type BuilderParams struct {
 	BuildContext   string
 	ImageOnly      bool
 	Executes       bool
+	IdxCache       *utils.CbuildIndexCache
 }
 
 type Options struct {
@@ -34,6 +36,8 @@ type Options struct {
 	Generator       string
 	Target          string
 	Contexts        []string
+	ExcludeContexts []string
+	Keep            []string
 	Filter          string
 	Load            string
 	Output          string
@@ -54,6 +58,27 @@ type Options struct {
 	UseCbuild2CMake bool
 	NoDatabase      bool
 	SkipConvert     bool
+	Progress        bool
+	DryRun          bool
+	Explain         bool
+	AllowEmpty      bool
+	Format          string
+	Diff            bool
+	MergeDatabase   string
+	DatabaseContext string
+	PathMaps        []string
+	RelativePaths   bool
+	VSCode          bool
+	DebugConfig     string
+	Analyzer        string
+	IncludePacks    bool
+	NoBuild         bool
+	TestRunner      string
+	TestTimeout     time.Duration
+	JUnitFile       string
+	Coverage        bool
+	CoverageFormat  string
+	GcovTool        string
 }
 
 type InternalVars struct {
@@ -65,6 +90,7 @@ type InternalVars struct {
 	CpackgetBin     string
 	CmakeBin        string
 	NinjaBin        string
+	MakeBin         string
 }
 
 type BuildDirs struct {
@@ -94,6 +120,7 @@ func (b BuilderParams) GetInternalVars() (vars InternalVars, err error) {
 	vars.XmllintBin, _ = exec.LookPath("xmllint")
 	vars.CmakeBin, _ = exec.LookPath("cmake")
 	vars.NinjaBin, _ = exec.LookPath("ninja")
+	vars.MakeBin, _ = exec.LookPath("make")
 
 	log.Debug("vars.binPath: " + vars.BinPath)
 	log.Debug("vars.etcPath: " + vars.EtcPath)
@@ -102,10 +129,20 @@ func (b BuilderParams) GetInternalVars() (vars InternalVars, err error) {
 	log.Debug("vars.xmllintBin: " + vars.XmllintBin)
 	log.Debug("vars.cmakeBin: " + vars.CmakeBin)
 	log.Debug("vars.ninjaBin: " + vars.NinjaBin)
+	log.Debug("vars.makeBin: " + vars.MakeBin)
 
 	return vars, err
 }
 
+// ParseCbuildIndexFile parses the cbuild-idx file, reusing the parsed content
+// of a previous call when a cache is provided
+func (b BuilderParams) ParseCbuildIndexFile(cbuildIndexFile string) (utils.CbuildIndex, error) {
+	if b.IdxCache != nil {
+		return b.IdxCache.Parse(cbuildIndexFile)
+	}
+	return utils.ParseCbuildIndexFile(cbuildIndexFile)
+}
+
 type IBuilderInterface interface {
 	Build() error
 	Clean() error
@@ -34,7 +34,7 @@ const (
 	ErrFetchingAbsPath        = "unable to get absolute path: '%s'"
 	ErrInvalidPath            = "invalid path: '%s'"
 	ErrPerfResults            = "unable to save performance results: %s"
-	ErrNoCompilerRegistered   = "required compiler(s) not registered: '%s'"
+	ErrNoCompilerRegistered   = "required compiler(s) not registered: '%s'. Register them in 'toolchains.yml' or with <name>_TOOLCHAIN_<major>_<minor>_<patch> environment variables"
 	ErrInvalidTargetSetUsage  = "invalid target-set usage. The '-a' option cannot be used with the '-c' or '-S'"
 	ErrInvalidSetUpArgs       = "invalid command line arguments. Options '-a' and '-S' are mutually exclusive"
 	ErrInvalidInputArg        = "invalid input argument for '%s'"
@@ -47,10 +47,41 @@ const (
 	ErrRelativizeClayerPath   = "unable to relativize clayer path %q against project file %q: %v"
 	ErrMissingModuleArg       = "--module is required"
 	ErrMissingClayerArg       = "--clayer requires at least one layer"
+	ErrMissingParam           = "missing required parameter '%s'"
+	ErrMissingTransport       = "missing transport option. Supported: '--stdio'"
+	ErrCMakeCacheEntry        = "%s not found in '%s'"
+	ErrUnknownSolutionDir     = "former solution directory of the build tree '%s' is unknown, build the solution again"
+	ErrInvalidContextRegex    = "invalid context regular expression '%s': %v"
+	ErrUnknownTargetSet       = "unknown target-set '%s'"
+	ErrUnknownToolchain       = "unknown toolchain '%s'"
+	ErrDidYouMean             = "%s. Did you mean %s?"
+	ErrInvalidFormat          = "invalid output format '%s'. Supported: %s"
+	ErrInvalidDiffContexts    = "comparing components requires exactly two contexts, %d selected"
+	ErrChecksFailed           = "%d check(s) failed"
+	ErrInvalidToolchainEntry  = "invalid toolchain entry in '%s': %s"
+	ErrInvalidConfigFile      = "invalid configuration file '%s': %v"
+	ErrUnknownProfile         = "unknown profile '%s'"
+	ErrInvalidConfigValue     = "invalid value '%s' of option '%s' from %s: %v"
+	ErrInvalidDatabaseContext = "context '%s' of the merged database is not selected"
+	ErrInvalidPathMap         = "invalid path mapping '%s', expected <from>=<to>"
+	ErrInvalidVSCodeFile      = "unable to update '%s', keeping the file unchanged: %v"
+	ErrInvalidDebugConfig     = "invalid debug configuration '%s'. Supported: %s"
+	ErrUnknownAnalyzer        = "unknown analyzer '%s'. Supported: %s"
+	ErrAnalyzerNotFound       = "%s not found, install it and add it to the PATH"
+	ErrAnalyzerFailed         = "%s failed on %d file(s)"
+	ErrNoSourcesToAnalyze     = "no source files to analyze, run 'cbuild setup' to generate the compile_commands.json files"
+	ErrNoTestExecutables      = "no test executables found in the selected contexts"
+	ErrTestsFailed            = "%d test(s) failed"
+	ErrUnknownCoverageFormat  = "unknown coverage format '%s'. Supported: %s"
+	ErrInvalidCoverageData    = "invalid coverage data of '%s': %v"
+	ErrGcovNotFound           = "%s not found, coverage requires gcov of GCC 9 or later in the PATH"
+	ErrUnclosedQuote          = "unclosed quote in command line '%s'"
+	ErrGcRequiresIdx          = "deleting orphaned directories requires the output directories of '%s', run 'cbuild setup' first"
 )
 
 const (
-	WarnNinjaVersion = "use Ninja 1.11.1 or higher for less verbose output"
+	WarnNinjaVersion      = "use Ninja 1.11.1 or higher for less verbose output"
+	WarnGeneratorFallback = "ninja not found, falling back to '%s' generator"
 )
 
 func New(errorFormat string, args ...any) error {
@@ -1,5 +1,5 @@
 /*
- * Copyright (c) 2023-2024 Arm Limited. All rights reserved.
+ * Copyright (c) 2023-2026 Arm Limited. All rights reserved.
  *
  * SPDX-License-Identifier: Apache-2.0
  */
@@ -49,6 +49,9 @@ func TestInitialization(testRoot string, testDir string) {
 	westBin := westDir + "/west" + binExtension
 	//nolint:gosec // G306: executable permissions required for test binary
 	_ = os.WriteFile(westBin, []byte("#!/usr/bin/env bash\n"), 0755)
+	makeBin := westDir + "/make" + binExtension
+	//nolint:gosec // G306: executable permissions required for test binary
+	_ = os.WriteFile(makeBin, []byte("#!/usr/bin/env bash\n"), 0755)
 	_ = os.Setenv("PATH", westDir+string(os.PathListSeparator)+os.Getenv("PATH"))
 }
 
@@ -0,0 +1,321 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/builder/csolution"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+// Params holds the request parameters. The fields correspond to the
+// command line options of the 'cbuild' and 'cbuild setup' commands.
+type Params struct {
+	Solution        string   `json:"solution"`
+	Contexts        []string `json:"contexts"`
+	ExcludeContexts []string `json:"excludeContexts"`
+	AllowEmpty      bool     `json:"allowEmpty"`
+	ContextSet      bool     `json:"contextSet"`
+	Active          *string  `json:"active"`
+	Toolchain       string   `json:"toolchain"`
+	Output          string   `json:"output"`
+	Load            string   `json:"load"`
+	Target          string   `json:"target"`
+	Generator       string   `json:"generator"`
+	Jobs            int      `json:"jobs"`
+	Packs           bool     `json:"packs"`
+	Rebuild         bool     `json:"rebuild"`
+	UpdateRte       bool     `json:"updateRte"`
+	FrozenPacks     bool     `json:"frozenPacks"`
+	NoSchemaCheck   bool     `json:"noSchemaCheck"`
+	NoDatabase      bool     `json:"noDatabase"`
+	MergeDatabase   string   `json:"mergeDatabase"`
+	DatabaseContext string   `json:"databaseContext"`
+	PathMaps        []string `json:"pathMaps"`
+	RelativePaths   bool     `json:"relativePaths"`
+	VSCode          bool     `json:"vscode"`
+	DebugConfig     string   `json:"debugConfig"`
+	SkipConvert     bool     `json:"skipConvert"`
+	Verbose         bool     `json:"verbose"`
+	Debug           bool     `json:"debug"`
+}
+
+type ContextInfo struct {
+	Context string `json:"context"`
+	Cbuild  string `json:"cbuild,omitempty"`
+}
+
+type ContextsResult struct {
+	Contexts []ContextInfo `json:"contexts"`
+}
+
+type ToolchainsResult struct {
+	Toolchains []string `json:"toolchains"`
+}
+
+type TargetSetsResult struct {
+	TargetSets []string `json:"targetSets"`
+}
+
+type EnvironmentResult struct {
+	Environment []string `json:"environment"`
+}
+
+type BuildResult struct {
+	CbuildIdx string `json:"cbuildIdx,omitempty"`
+}
+
+type InitializeResult struct {
+	Version string   `json:"version"`
+	Methods []string `json:"methods"`
+}
+
+type handlerFunc func(s *Server, ctx context.Context, params Params) (any, error)
+
+var handlers map[string]handlerFunc
+
+func init() {
+	handlers = map[string]handlerFunc{
+		"initialize":      (*Server).initialize,
+		"shutdown":        (*Server).shutdown,
+		"listContexts":    (*Server).listContexts,
+		"listToolchains":  (*Server).listToolchains,
+		"listTargetSets":  (*Server).listTargetSets,
+		"listEnvironment": (*Server).listEnvironment,
+		"setup":           (*Server).setup,
+		"build":           (*Server).build,
+		"clean":           (*Server).clean,
+	}
+}
+
+func (s *Server) handle(ctx context.Context, msg Message) (any, error) {
+	handler, ok := handlers[msg.Method]
+	if !ok {
+		return nil, &ResponseError{Code: CodeMethodNotFound, Message: "method not found: '" + msg.Method + "'"}
+	}
+
+	var params Params
+	if len(msg.Params) > 0 && string(msg.Params) != "null" {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &ResponseError{Code: CodeInvalidParams, Message: err.Error()}
+		}
+	}
+	return handler(s, ctx, params)
+}
+
+func invalidParams(err error) error {
+	return &ResponseError{Code: CodeInvalidParams, Message: err.Error()}
+}
+
+func (s *Server) initialize(_ context.Context, _ Params) (any, error) {
+	methods := make([]string, 0, len(handlers))
+	for method := range handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return InitializeResult{Version: s.Version, Methods: methods}, nil
+}
+
+func (s *Server) shutdown(_ context.Context, _ Params) (any, error) {
+	return nil, nil
+}
+
+// checkSolution validates the 'solution' parameter
+func checkSolution(solution string) error {
+	if solution == "" {
+		return invalidParams(errutils.New(errutils.ErrMissingParam, "solution"))
+	}
+	if err := utils.CheckCsolutionFile(solution); err != nil {
+		return invalidParams(err)
+	}
+	return nil
+}
+
+func (s *Server) newBuilder(ctx context.Context, params Params, setup bool) csolution.CSolutionBuilder {
+	generator := params.Generator
+	if generator == "" {
+		generator = "Ninja"
+	}
+	jobs := params.Jobs
+	if jobs <= 0 {
+		jobs = 8
+	}
+	var targetSet string
+	if params.Active != nil {
+		targetSet = *params.Active
+	}
+
+	options := builder.Options{
+		Generator:       generator,
+		Target:          params.Target,
+		Jobs:            jobs,
+		Debug:           params.Debug,
+		Verbose:         params.Verbose,
+		SchemaChk:       !params.NoSchemaCheck,
+		Packs:           params.Packs,
+		Rebuild:         params.Rebuild,
+		UpdateRte:       params.UpdateRte,
+		Contexts:        params.Contexts,
+		ExcludeContexts: params.ExcludeContexts,
+		AllowEmpty:      params.AllowEmpty,
+		UseContextSet:   params.ContextSet,
+		Load:            params.Load,
+		Output:          params.Output,
+		Toolchain:       params.Toolchain,
+		FrozenPacks:     params.FrozenPacks,
+		UseCbuild2CMake: true,
+		NoDatabase:      params.NoDatabase,
+		MergeDatabase:   params.MergeDatabase,
+		DatabaseContext: params.DatabaseContext,
+		PathMaps:        params.PathMaps,
+		RelativePaths:   params.RelativePaths,
+		VSCode:          params.VSCode,
+		DebugConfig:     params.DebugConfig,
+		TargetSet:       targetSet,
+		UseTargetSet:    params.Active != nil,
+		SkipConvert:     params.SkipConvert,
+	}
+
+	return csolution.CSolutionBuilder{
+		BuilderParams: builder.BuilderParams{
+			Runner:         s.NewRunner(ctx, s.progress),
+			Options:        options,
+			InputFile:      params.Solution,
+			InstallConfigs: s.InstallConfigs,
+			Setup:          setup,
+			IdxCache:       s.idxCache,
+		},
+	}
+}
+
+func (s *Server) listContexts(ctx context.Context, params Params) (any, error) {
+	if err := checkSolution(params.Solution); err != nil {
+		return nil, err
+	}
+	b := s.newBuilder(ctx, params, false)
+	contexts, err := b.GetContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	// enrich the contexts with the cbuild files of an earlier setup or build
+	cbuildFiles := make(map[string]string)
+	if idxFile, err := b.GetIdxFilePath(); err == nil {
+		if idx, err := s.idxCache.Parse(idxFile); err == nil {
+			for _, cbuild := range idx.BuildIdx.Cbuilds {
+				cbuildFiles[cbuild.Project+cbuild.Configuration] = filepath.Join(filepath.Dir(idxFile), cbuild.Cbuild)
+			}
+		}
+	}
+
+	result := ContextsResult{Contexts: []ContextInfo{}}
+	for _, context := range contexts {
+		result.Contexts = append(result.Contexts, ContextInfo{Context: context, Cbuild: cbuildFiles[context]})
+	}
+	return result, nil
+}
+
+func (s *Server) listToolchains(ctx context.Context, params Params) (any, error) {
+	if params.Solution != "" {
+		if err := checkSolution(params.Solution); err != nil {
+			return nil, err
+		}
+	}
+	toolchains, err := s.newBuilder(ctx, params, false).GetToolchains()
+	if err != nil {
+		return nil, err
+	}
+	if toolchains == nil {
+		toolchains = []string{}
+	}
+	return ToolchainsResult{Toolchains: toolchains}, nil
+}
+
+func (s *Server) listTargetSets(ctx context.Context, params Params) (any, error) {
+	if err := checkSolution(params.Solution); err != nil {
+		return nil, err
+	}
+	targetSets, err := s.newBuilder(ctx, params, false).GetTargetSets()
+	if err != nil {
+		return nil, err
+	}
+	if targetSets == nil {
+		targetSets = []string{}
+	}
+	return TargetSetsResult{TargetSets: targetSets}, nil
+}
+
+func (s *Server) listEnvironment(ctx context.Context, params Params) (any, error) {
+	environment, err := s.newBuilder(ctx, params, false).GetEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	if environment == nil {
+		environment = []string{}
+	}
+	return EnvironmentResult{Environment: environment}, nil
+}
+
+func (s *Server) setup(ctx context.Context, params Params) (any, error) {
+	if err := checkSolution(params.Solution); err != nil {
+		return nil, err
+	}
+	// same constraints as the 'cbuild setup' command
+	if params.Active != nil && params.ContextSet {
+		return nil, invalidParams(errutils.New(errutils.ErrInvalidSetUpArgs))
+	}
+	if params.Active == nil && !params.ContextSet {
+		return nil, invalidParams(errutils.New(errutils.ErrMissingRequiredArg))
+	}
+	return s.runBuild(ctx, params, true)
+}
+
+func (s *Server) build(ctx context.Context, params Params) (any, error) {
+	if err := checkSolution(params.Solution); err != nil {
+		return nil, err
+	}
+	if params.Active != nil && (len(params.Contexts) > 0 || len(params.ExcludeContexts) > 0 || params.ContextSet) {
+		return nil, invalidParams(errutils.New(errutils.ErrInvalidTargetSetUsage))
+	}
+	return s.runBuild(ctx, params, false)
+}
+
+func (s *Server) runBuild(ctx context.Context, params Params, setup bool) (any, error) {
+	b := s.newBuilder(ctx, params, setup)
+	if params.Rebuild {
+		if err := b.Clean(); err != nil {
+			return nil, err
+		}
+	}
+	if err := b.Build(); err != nil {
+		return nil, err
+	}
+
+	var result BuildResult
+	if idxFile, err := b.GetIdxFilePath(); err == nil {
+		if _, err := s.idxCache.Parse(idxFile); err == nil {
+			result.CbuildIdx = idxFile
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) clean(ctx context.Context, params Params) (any, error) {
+	if err := checkSolution(params.Solution); err != nil {
+		return nil, err
+	}
+	if err := s.newBuilder(ctx, params, false).Clean(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const jsonRPCVersion = "2.0"
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError       = -32700
+	CodeInvalidRequest   = -32600
+	CodeMethodNotFound   = -32601
+	CodeInvalidParams    = -32602
+	CodeInternalError    = -32603
+	CodeRequestCancelled = -32800
+)
+
+// Message is the union of JSON-RPC requests, notifications and responses
+type Message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
+}
+
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return e.Message
+}
+
+// isNotification reports whether the message expects no response
+func (m Message) isNotification() bool {
+	return m.ID == nil
+}
+
+// readMessage reads one message framed with a 'Content-Length' header
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	contentLength := -1
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if contentLength < 0 {
+				// tolerate blank lines between messages
+				continue
+			}
+			break
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid header line: '%s'", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || contentLength < 0 {
+				return nil, fmt.Errorf("invalid Content-Length: '%s'", strings.TrimSpace(value))
+			}
+		}
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// writeMessage writes one message framed with a 'Content-Length' header
+func writeMessage(writer io.Writer, msg Message) error {
+	msg.JSONRPC = jsonRPCVersion
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(writer, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = writer.Write(body)
+	return err
+}
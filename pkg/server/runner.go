@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+// lineWriter splits the written bytes into lines and passes each complete line to emit
+type lineWriter struct {
+	mutex   sync.Mutex
+	pending []byte
+	emit    func(line string)
+}
+
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.pending = append(w.pending, data...)
+	for {
+		index := bytes.IndexByte(w.pending, '\n')
+		if index < 0 {
+			break
+		}
+		line := strings.TrimRight(string(w.pending[:index]), "\r")
+		w.pending = w.pending[index+1:]
+		w.emit(line)
+	}
+	return len(data), nil
+}
+
+// Flush emits any remaining incomplete line
+func (w *lineWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.pending) > 0 {
+		w.emit(strings.TrimRight(string(w.pending), "\r"))
+		w.pending = nil
+	}
+}
+
+// streamRunner executes the tools for a single request. The output of
+// non-quiet commands is streamed line by line as progress notifications
+// and the running process is killed when the request is cancelled.
+type streamRunner struct {
+	ctx    context.Context
+	notify func(line string)
+	env    []string
+}
+
+func NewStreamRunner(ctx context.Context, notify func(line string)) utils.RunnerInterface {
+	return streamRunner{ctx: ctx, notify: notify}
+}
+
+func (r streamRunner) WithEnv(env []string) utils.RunnerInterface {
+	r.env = append(slices.Clone(r.env), env...)
+	return r
+}
+
+func (r streamRunner) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	if err := r.ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var stdout bytes.Buffer
+	output := &lineWriter{emit: func(line string) {
+		if !quiet {
+			r.notify(line)
+		}
+	}}
+
+	cmd := exec.CommandContext(r.ctx, program, args...)
+	cmd.Env = utils.CommandEnv(r.env)
+	cmd.Stdout = &teeWriter{buffer: &stdout, lines: output}
+	cmd.Stderr = output
+	err := cmd.Run()
+	output.Flush()
+
+	if ctxErr := r.ctx.Err(); ctxErr != nil {
+		return stdout.String(), ctxErr
+	}
+	return stdout.String(), err
+}
+
+// teeWriter captures the standard output while streaming it
+type teeWriter struct {
+	buffer *bytes.Buffer
+	lines  *lineWriter
+}
+
+func (w *teeWriter) Write(data []byte) (int, error) {
+	w.buffer.Write(data)
+	return w.lines.Write(data)
+}
@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+)
+
+// Server answers JSON-RPC 2.0 requests of IDE integrations over a byte stream.
+// Requests are executed one at a time in arrival order, while cancellation
+// requests are handled immediately. The queue of requests is unbounded, so
+// reading the input never waits for running requests.
+type Server struct {
+	Version        string
+	InstallConfigs utils.Configurations
+	NewRunner      func(ctx context.Context, notify func(line string)) utils.RunnerInterface
+
+	reader     *bufio.Reader
+	writer     io.Writer
+	writeMutex sync.Mutex
+
+	pendingMutex sync.Mutex
+	pending      map[string]context.CancelFunc
+
+	activeMutex sync.Mutex
+	activeID    *json.RawMessage
+
+	// keeps the parsed cbuild-idx files warm for the builders of all requests
+	idxCache *utils.CbuildIndexCache
+}
+
+type job struct {
+	ctx context.Context
+	msg Message
+}
+
+// jobQueue is an unbounded FIFO of requests waiting for execution
+type jobQueue struct {
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	jobs   []job
+	closed bool
+}
+
+func newJobQueue() *jobQueue {
+	q := &jobQueue{}
+	q.cond = sync.NewCond(&q.mutex)
+	return q
+}
+
+func (q *jobQueue) push(j job) {
+	q.mutex.Lock()
+	q.jobs = append(q.jobs, j)
+	q.mutex.Unlock()
+	q.cond.Signal()
+}
+
+// pop waits for the next job, it returns false when the queue is closed and empty
+func (q *jobQueue) pop() (job, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for len(q.jobs) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.jobs) == 0 {
+		return job{}, false
+	}
+	j := q.jobs[0]
+	q.jobs[0] = job{}
+	q.jobs = q.jobs[1:]
+	return j, true
+}
+
+func (q *jobQueue) close() {
+	q.mutex.Lock()
+	q.closed = true
+	q.mutex.Unlock()
+	q.cond.Broadcast()
+}
+
+func New(in io.Reader, out io.Writer, configs utils.Configurations) *Server {
+	return &Server{
+		InstallConfigs: configs,
+		NewRunner:      NewStreamRunner,
+		reader:         bufio.NewReader(in),
+		writer:         out,
+		pending:        make(map[string]context.CancelFunc),
+		idxCache:       utils.NewCbuildIndexCache(),
+	}
+}
+
+// Serve processes messages until the input is closed or an 'exit' notification
+// is received. On 'exit' the queued and running requests are cancelled.
+func (s *Server) Serve() error {
+	jobs := newJobQueue()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			j, ok := jobs.pop()
+			if !ok {
+				return
+			}
+			s.process(j)
+		}
+	}()
+
+	var err error
+	for {
+		var body []byte
+		body, err = readMessage(s.reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			break
+		}
+
+		var msg Message
+		if jsonErr := json.Unmarshal(body, &msg); jsonErr != nil {
+			s.respond(nil, nil, &ResponseError{Code: CodeParseError, Message: jsonErr.Error()})
+			continue
+		}
+		if msg.Method == "" {
+			// responses from the client are not expected
+			continue
+		}
+
+		if msg.Method == "exit" {
+			s.cancelAll()
+			break
+		}
+		if msg.Method == "$/cancelRequest" {
+			var params struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if json.Unmarshal(msg.Params, &params) == nil {
+				s.cancel(string(params.ID))
+			}
+			continue
+		}
+		if msg.isNotification() {
+			// unknown notifications are ignored
+			continue
+		}
+
+		ctx, ok := s.addPending(string(*msg.ID))
+		if !ok {
+			s.respond(msg.ID, nil, &ResponseError{Code: CodeInvalidRequest, Message: "request id " + string(*msg.ID) + " is already in use"})
+			continue
+		}
+		jobs.push(job{ctx: ctx, msg: msg})
+	}
+
+	jobs.close()
+	<-done
+	return err
+}
+
+// addPending registers a cancellable request, it fails for the id of a queued
+// or running request
+func (s *Server) addPending(id string) (context.Context, bool) {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+	if _, ok := s.pending[id]; ok {
+		return nil, false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.pending[id] = cancel
+	return ctx, true
+}
+
+func (s *Server) cancel(id string) {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+	if cancel, ok := s.pending[id]; ok {
+		cancel()
+	}
+}
+
+// cancelAll cancels the queued and the running requests
+func (s *Server) cancelAll() {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+	for _, cancel := range s.pending {
+		cancel()
+	}
+}
+
+func (s *Server) process(j job) {
+	id := string(*j.msg.ID)
+	defer func() {
+		s.pendingMutex.Lock()
+		if cancel, ok := s.pending[id]; ok {
+			cancel()
+			delete(s.pending, id)
+		}
+		s.pendingMutex.Unlock()
+	}()
+
+	if j.ctx.Err() != nil {
+		s.respond(j.msg.ID, nil, &ResponseError{Code: CodeRequestCancelled, Message: "request cancelled"})
+		return
+	}
+
+	s.setActive(j.msg.ID)
+	result, err := s.handle(j.ctx, j.msg)
+	s.setActive(nil)
+
+	if j.ctx.Err() != nil {
+		s.respond(j.msg.ID, nil, &ResponseError{Code: CodeRequestCancelled, Message: "request cancelled"})
+		return
+	}
+	if err != nil {
+		var respErr *ResponseError
+		if !errors.As(err, &respErr) {
+			respErr = &ResponseError{Code: CodeInternalError, Message: err.Error()}
+		}
+		s.respond(j.msg.ID, nil, respErr)
+		return
+	}
+	s.respond(j.msg.ID, result, nil)
+}
+
+func (s *Server) setActive(id *json.RawMessage) {
+	s.activeMutex.Lock()
+	s.activeID = id
+	s.activeMutex.Unlock()
+}
+
+func (s *Server) respond(id *json.RawMessage, result any, respErr *ResponseError) {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	if result == nil && respErr == nil {
+		result = struct{}{}
+	}
+	s.send(Message{ID: id, Result: result, Error: respErr})
+}
+
+func (s *Server) notify(method string, params any) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+	s.send(Message{Method: method, Params: data})
+}
+
+func (s *Server) send(msg Message) {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+	_ = writeMessage(s.writer, msg)
+}
+
+type progressParams struct {
+	ID      *json.RawMessage `json:"id"`
+	Message string           `json:"message"`
+}
+
+// progress streams an output line of the active request to the client
+func (s *Server) progress(line string) {
+	s.activeMutex.Lock()
+	id := s.activeID
+	s.activeMutex.Unlock()
+	s.notify("progress", progressParams{ID: id, Message: line})
+}
+
+// LogWriter returns a writer forwarding log messages as progress notifications,
+// keeping the output stream free for protocol messages
+func (s *Server) LogWriter() io.Writer {
+	return &lineWriter{emit: s.progress}
+}
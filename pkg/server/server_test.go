@@ -0,0 +1,318 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/inittest"
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoot = "../../test"
+const testDir = "server"
+
+var configs inittest.TestConfigs
+
+func init() {
+	inittest.TestInitialization(testRoot, testDir)
+	configs = inittest.GetTestConfigs(testRoot, testDir)
+}
+
+type RunnerMock struct {
+	notify func(line string)
+}
+
+func (r RunnerMock) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	if strings.Contains(program, "csolution") && len(args) > 1 && args[0] == "list" {
+		switch args[1] {
+		case "contexts":
+			return "test.Debug+CM0\r\ntest.Release+CM0", nil
+		case "toolchains":
+			return "AC6@6.18.0\nGCC@11.2.1\n", nil
+		}
+	}
+	if !quiet {
+		r.notify(filepath.Base(program) + " " + strings.Join(args, " "))
+	}
+	return "", nil
+}
+
+func newTestServer(in io.Reader, out io.Writer) *Server {
+	s := New(in, out, utils.Configurations{
+		BinPath: configs.BinPath,
+		BinExtn: configs.BinExtn,
+		EtcPath: configs.EtcPath,
+	})
+	s.NewRunner = func(_ context.Context, notify func(line string)) utils.RunnerInterface {
+		return RunnerMock{notify: notify}
+	}
+	return s
+}
+
+func request(id int, method string, params any) string {
+	msg := map[string]any{"jsonrpc": "2.0", "id": id, "method": method}
+	if params != nil {
+		msg["params"] = params
+	}
+	body, _ := json.Marshal(msg)
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func readAll(t *testing.T, output []byte) (responses map[string]Message, notifications []Message) {
+	t.Helper()
+	responses = make(map[string]Message)
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for {
+		body, err := readMessage(reader)
+		if err != nil {
+			break
+		}
+		var msg Message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Method != "" {
+			notifications = append(notifications, msg)
+		} else if msg.ID == nil {
+			responses["null"] = msg
+		} else {
+			responses[string(*msg.ID)] = msg
+		}
+	}
+	return
+}
+
+func TestReadMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("framed message", func(t *testing.T) {
+		reader := bufio.NewReader(strings.NewReader("\r\nContent-Length: 2\r\nContent-Type: application/json\r\n\r\n{}"))
+		body, err := readMessage(reader)
+		assert.Nil(err)
+		assert.Equal("{}", string(body))
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		reader := bufio.NewReader(strings.NewReader("Content-Length 2\r\n\r\n{}"))
+		_, err := readMessage(reader)
+		assert.Error(err)
+	})
+
+	t.Run("invalid content length", func(t *testing.T) {
+		reader := bufio.NewReader(strings.NewReader("Content-Length: abc\r\n\r\n{}"))
+		_, err := readMessage(reader)
+		assert.Error(err)
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		reader := bufio.NewReader(strings.NewReader("Content-Length: 10\r\n\r\n{}"))
+		_, err := readMessage(reader)
+		assert.Error(err)
+	})
+}
+
+func TestLineWriter(t *testing.T) {
+	assert := assert.New(t)
+	var lines []string
+	writer := &lineWriter{emit: func(line string) { lines = append(lines, line) }}
+	_, _ = writer.Write([]byte("first\r\nsec"))
+	_, _ = writer.Write([]byte("ond\nthird"))
+	assert.Equal([]string{"first", "second"}, lines)
+	writer.Flush()
+	assert.Equal([]string{"first", "second", "third"}, lines)
+}
+
+func TestServe(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+
+	t.Run("initialize and unknown method", func(t *testing.T) {
+		input := request(1, "initialize", nil) + request(2, "unknown", nil)
+		var output bytes.Buffer
+		s := newTestServer(strings.NewReader(input), &output)
+		s.Version = "2.0.0"
+		assert.Nil(s.Serve())
+
+		responses, _ := readAll(t, output.Bytes())
+		assert.Len(responses, 2)
+		assert.Nil(responses["1"].Error)
+		result := responses["1"].Result.(map[string]any)
+		assert.Equal("2.0.0", result["version"])
+		assert.Contains(result["methods"], "build")
+		assert.Equal(CodeMethodNotFound, responses["2"].Error.Code)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		input := "Content-Length: 5\r\n\r\n{abc}" + request(1, "shutdown", nil)
+		var output bytes.Buffer
+		s := newTestServer(strings.NewReader(input), &output)
+		assert.Nil(s.Serve())
+
+		responses, _ := readAll(t, output.Bytes())
+		assert.Equal(CodeParseError, responses["null"].Error.Code)
+		assert.Nil(responses["1"].Error)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		input := request(1, "listContexts", nil) +
+			request(2, "listContexts", map[string]any{"solution": "test.cprj"}) +
+			request(3, "setup", map[string]any{"solution": csolutionFile}) +
+			request(4, "build", map[string]any{"solution": csolutionFile, "active": "CM0", "contextSet": true}) +
+			request(5, "build", map[string]any{"solution": 5})
+		var output bytes.Buffer
+		s := newTestServer(strings.NewReader(input), &output)
+		assert.Nil(s.Serve())
+
+		responses, _ := readAll(t, output.Bytes())
+		assert.Len(responses, 5)
+		for _, response := range responses {
+			assert.Equal(CodeInvalidParams, response.Error.Code)
+		}
+	})
+
+	t.Run("list contexts and toolchains", func(t *testing.T) {
+		input := request(1, "listContexts", map[string]any{"solution": csolutionFile}) +
+			request(2, "listToolchains", nil)
+		var output bytes.Buffer
+		s := newTestServer(strings.NewReader(input), &output)
+		assert.Nil(s.Serve())
+
+		responses, _ := readAll(t, output.Bytes())
+		assert.Nil(responses["1"].Error)
+		contexts := responses["1"].Result.(map[string]any)["contexts"].([]any)
+		assert.Len(contexts, 2)
+		assert.Equal("test.Debug+CM0", contexts[0].(map[string]any)["context"])
+		assert.Nil(responses["2"].Error)
+		assert.Equal([]any{"AC6@6.18.0", "GCC@11.2.1"}, responses["2"].Result.(map[string]any)["toolchains"])
+	})
+
+	t.Run("exit stops processing", func(t *testing.T) {
+		exit := `{"jsonrpc":"2.0","method":"exit"}`
+		input := fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(exit), exit) + request(1, "initialize", nil)
+		var output bytes.Buffer
+		s := newTestServer(strings.NewReader(input), &output)
+		assert.Nil(s.Serve())
+		assert.Empty(output.Bytes())
+	})
+}
+
+type blockingRunner struct {
+	ctx     context.Context
+	started chan struct{}
+}
+
+func (r blockingRunner) ExecuteCommand(_ string, _ bool, _ ...string) (string, error) {
+	close(r.started)
+	<-r.ctx.Done()
+	return "", r.ctx.Err()
+}
+
+// startBlockingServer serves the requests written to the returned writer with
+// build runners blocking until the request is cancelled
+func startBlockingServer() (writer *io.PipeWriter, output *bytes.Buffer, started chan struct{}, done chan error) {
+	reader, writer := io.Pipe()
+	output = &bytes.Buffer{}
+	s := newTestServer(reader, output)
+	started = make(chan struct{})
+	s.NewRunner = func(ctx context.Context, _ func(line string)) utils.RunnerInterface {
+		return blockingRunner{ctx: ctx, started: started}
+	}
+	done = make(chan error)
+	go func() { done <- s.Serve() }()
+	return writer, output, started, done
+}
+
+func notification(method string, params string) string {
+	body := `{"jsonrpc":"2.0","method":"` + method + `"`
+	if params != "" {
+		body += `,"params":` + params
+	}
+	body += "}"
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func TestCancelRequest(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+
+	writer, output, started, done := startBlockingServer()
+	_, _ = io.WriteString(writer, request(1, "build", map[string]any{"solution": csolutionFile}))
+	<-started
+	_, _ = io.WriteString(writer, notification("$/cancelRequest", `{"id":1}`))
+	_ = writer.Close()
+	assert.Nil(<-done)
+
+	responses, _ := readAll(t, output.Bytes())
+	assert.Equal(CodeRequestCancelled, responses["1"].Error.Code)
+}
+
+func TestExitCancelsRequests(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+
+	writer, output, started, done := startBlockingServer()
+	defer writer.Close()
+	_, _ = io.WriteString(writer, request(1, "build", map[string]any{"solution": csolutionFile}))
+	<-started
+	// queue more requests than fit into a buffer of the reader, the reader
+	// must still receive the 'exit' notification
+	for id := 2; id <= 200; id++ {
+		_, _ = io.WriteString(writer, request(id, "build", map[string]any{"solution": csolutionFile}))
+	}
+	_, _ = io.WriteString(writer, notification("exit", ""))
+
+	select {
+	case err := <-done:
+		assert.Nil(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("server did not stop on exit")
+	}
+	responses, _ := readAll(t, output.Bytes())
+	assert.Len(responses, 200)
+	for _, response := range responses {
+		assert.Equal(CodeRequestCancelled, response.Error.Code)
+	}
+}
+
+func TestDuplicateRequestID(t *testing.T) {
+	assert := assert.New(t)
+	csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+
+	writer, output, started, done := startBlockingServer()
+	_, _ = io.WriteString(writer, request(1, "build", map[string]any{"solution": csolutionFile}))
+	<-started
+	_, _ = io.WriteString(writer, request(1, "build", map[string]any{"solution": csolutionFile}))
+	_, _ = io.WriteString(writer, notification("$/cancelRequest", `{"id":1}`))
+	_ = writer.Close()
+	assert.Nil(<-done)
+
+	var codes []int
+	reader := bufio.NewReader(bytes.NewReader(output.Bytes()))
+	for {
+		body, err := readMessage(reader)
+		if err != nil {
+			break
+		}
+		var msg Message
+		assert.Nil(json.Unmarshal(body, &msg))
+		assert.Equal("1", string(*msg.ID))
+		codes = append(codes, msg.Error.Code)
+	}
+	// the duplicate is rejected, the cancellation still reaches the running request
+	assert.Equal([]int{CodeInvalidRequest, CodeRequestCancelled}, codes)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	"github.com/spf13/pflag"
+)
+
+const (
+	CbuildConfigFile = "cbuild.config.yml"
+	CbuildEnvPrefix  = "CBUILD_"
+
+	// ConfiguredAnnotation marks the options set from the environment or a cbuild.config.yml
+	ConfiguredAnnotation = "cbuild_configured"
+)
+
+// CbuildConfig holds option defaults and named profiles, the keys are
+// the long names of the command line options
+type CbuildConfig struct {
+	Defaults map[string]any            `yaml:"defaults"`
+	Profiles map[string]map[string]any `yaml:"profiles"`
+}
+
+// GetUserCbuildConfigFile returns the path of the user-level cbuild.config.yml
+func GetUserCbuildConfigFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "cbuild", CbuildConfigFile)
+}
+
+// LoadCbuildConfig reads the user-level and the solution-level cbuild.config.yml,
+// values of the solution override values of the user
+func LoadCbuildConfig(solutionDir string) (config CbuildConfig, err error) {
+	config.Defaults = make(map[string]any)
+	config.Profiles = make(map[string]map[string]any)
+
+	var files []string
+	if userFile := GetUserCbuildConfigFile(); userFile != "" {
+		files = append(files, userFile)
+	}
+	if solutionDir != "" {
+		files = append(files, filepath.Join(solutionDir, CbuildConfigFile))
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		var fileConfig CbuildConfig
+		if err := ParseYAMLFile(file, &fileConfig); err != nil {
+			return config, errutils.New(errutils.ErrInvalidConfigFile, file, err)
+		}
+		for key, value := range fileConfig.Defaults {
+			config.Defaults[key] = value
+		}
+		for name, profile := range fileConfig.Profiles {
+			if config.Profiles[name] == nil {
+				config.Profiles[name] = make(map[string]any)
+			}
+			for key, value := range profile {
+				config.Profiles[name][key] = value
+			}
+		}
+	}
+	return config, nil
+}
+
+// GetValues returns the option values of the defaults overridden by the values
+// of the profile, if any
+func (c CbuildConfig) GetValues(profile string) (map[string]string, error) {
+	values := make(map[string]string)
+	for key, value := range c.Defaults {
+		values[key] = configValueString(value)
+	}
+	if profile == "" {
+		return values, nil
+	}
+
+	profileValues, ok := c.Profiles[profile]
+	if !ok {
+		var names []string
+		for name := range c.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, AddSuggestions(errutils.New(errutils.ErrUnknownProfile, profile), profile, names)
+	}
+	for key, value := range profileValues {
+		values[key] = configValueString(value)
+	}
+	return values, nil
+}
+
+// configValueString converts a YAML value into its command line representation,
+// lists become comma separated values
+func configValueString(value any) string {
+	if list, ok := value.([]any); ok {
+		var items []string
+		for _, item := range list {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// GetOptionEnvVar returns the name of the environment variable of an option,
+// e.g. 'CBUILD_NO_SCHEMA_CHECK' for '--no-schema-check'
+func GetOptionEnvVar(option string) string {
+	return CbuildEnvPrefix + strings.ToUpper(strings.ReplaceAll(option, "-", "_"))
+}
+
+// IsOptionSet reports whether an option was given on the command line or set from
+// the environment or a cbuild.config.yml. Configured options keep their 'Changed'
+// state unset, so only options whose presence selects a mode need to check this.
+func IsOptionSet(flags *pflag.FlagSet, name string) bool {
+	flag := flags.Lookup(name)
+	if flag == nil {
+		return false
+	}
+	_, configured := flag.Annotations[ConfiguredAnnotation]
+	return flag.Changed || configured
+}
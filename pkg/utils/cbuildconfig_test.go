@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCbuildConfig(t *testing.T) {
+	assert := assert.New(t)
+	userDir := t.TempDir()
+	solutionDir := t.TempDir()
+	setUserConfigDir(t, userDir)
+
+	userFile := GetUserCbuildConfigFile()
+	assert.Nil(os.MkdirAll(filepath.Dir(userFile), 0755))
+	assert.Nil(os.WriteFile(userFile, []byte("defaults:\n  jobs: 16\n  packs: true\n"+
+		"profiles:\n  ci:\n    toolchain: GCC\n    output: build\n"), 0600))
+	assert.Nil(os.WriteFile(filepath.Join(solutionDir, CbuildConfigFile), []byte("defaults:\n  jobs: 4\n"+
+		"profiles:\n  ci:\n    toolchain: AC6\n  release:\n    context:\n      - .Release\n      - '!test.Release+CM3'\n"), 0600))
+
+	t.Run("merge user and solution configuration", func(t *testing.T) {
+		config, err := LoadCbuildConfig(solutionDir)
+		assert.Nil(err)
+
+		values, err := config.GetValues("")
+		assert.Nil(err)
+		assert.Equal(map[string]string{"jobs": "4", "packs": "true"}, values)
+
+		values, err = config.GetValues("ci")
+		assert.Nil(err)
+		assert.Equal(map[string]string{"jobs": "4", "packs": "true", "toolchain": "AC6", "output": "build"}, values)
+
+		values, err = config.GetValues("release")
+		assert.Nil(err)
+		assert.Equal(".Release,!test.Release+CM3", values["context"])
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		config, err := LoadCbuildConfig(solutionDir)
+		assert.Nil(err)
+		_, err = config.GetValues("cl")
+		assert.EqualError(err, "unknown profile 'cl'. Did you mean 'ci'?")
+	})
+
+	t.Run("user configuration only", func(t *testing.T) {
+		config, err := LoadCbuildConfig("")
+		assert.Nil(err)
+		values, err := config.GetValues("ci")
+		assert.Nil(err)
+		assert.Equal("GCC", values["toolchain"])
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		file := filepath.Join(solutionDir, CbuildConfigFile)
+		assert.Nil(os.WriteFile(file, []byte("defaults: [jobs]\n"), 0600))
+		_, err := LoadCbuildConfig(solutionDir)
+		assert.ErrorContains(err, "invalid configuration file '"+file+"'")
+	})
+}
+
+func TestGetOptionEnvVar(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("CBUILD_JOBS", GetOptionEnvVar("jobs"))
+	assert.Equal("CBUILD_NO_SCHEMA_CHECK", GetOptionEnvVar("no-schema-check"))
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CbuildIndexCache keeps the parsed cbuild-idx files of a long running process,
+// e.g. 'cbuild serve', until their content changes. The callers receive copies
+// of the cached data and may modify them.
+type CbuildIndexCache struct {
+	mutex   sync.Mutex
+	entries map[string]cachedCbuildIndex
+}
+
+type cachedCbuildIndex struct {
+	hash [sha256.Size]byte
+	data CbuildIndex
+}
+
+func NewCbuildIndexCache() *CbuildIndexCache {
+	return &CbuildIndexCache{entries: make(map[string]cachedCbuildIndex)}
+}
+
+// Parse returns the parsed cbuild-idx file, the file is parsed again when its
+// content differs from the cached one
+func (c *CbuildIndexCache) Parse(cbuildIndexFile string) (data CbuildIndex, err error) {
+	content, err := os.ReadFile(cbuildIndexFile)
+	if err != nil {
+		return data, err
+	}
+	hash := sha256.Sum256(content)
+	key, _ := filepath.Abs(cbuildIndexFile)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if cached, ok := c.entries[key]; ok && cached.hash == hash {
+		return cached.data.clone(), nil
+	}
+	if err = yaml.Unmarshal(content, &data); err != nil {
+		delete(c.entries, key)
+		return data, err
+	}
+	c.entries[key] = cachedCbuildIndex{hash: hash, data: data.clone()}
+	return data, nil
+}
+
+// clone returns a deep copy of the parsed cbuild-idx file
+func (idx CbuildIndex) clone() CbuildIndex {
+	clone := idx
+	clone.BuildIdx.Cprojects = slices.Clone(idx.BuildIdx.Cprojects)
+	clone.BuildIdx.Licenses = cloneYAMLValue(idx.BuildIdx.Licenses)
+	clone.BuildIdx.Cbuilds = slices.Clone(idx.BuildIdx.Cbuilds)
+	for i := range clone.BuildIdx.Cbuilds {
+		messages := &clone.BuildIdx.Cbuilds[i].Messages
+		messages.Warnings = slices.Clone(messages.Warnings)
+		messages.Info = slices.Clone(messages.Info)
+	}
+	if idx.BuildIdx.Executes != nil {
+		clone.BuildIdx.Executes = make([]interface{}, len(idx.BuildIdx.Executes))
+		for i, execute := range idx.BuildIdx.Executes {
+			clone.BuildIdx.Executes[i] = cloneYAMLValue(execute)
+		}
+	}
+	return clone
+}
+
+// cloneYAMLValue returns a deep copy of a generically unmarshalled YAML value
+func cloneYAMLValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			clone[key] = cloneYAMLValue(item)
+		}
+		return clone
+	case map[interface{}]interface{}:
+		clone := make(map[interface{}]interface{}, len(value))
+		for key, item := range value {
+			clone[key] = cloneYAMLValue(item)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(value))
+		for i, item := range value {
+			clone[i] = cloneYAMLValue(item)
+		}
+		return clone
+	default:
+		return value
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCbuildIndexCache(t *testing.T) {
+	assert := assert.New(t)
+	cache := NewCbuildIndexCache()
+
+	idxFile := filepath.Join(t.TempDir(), "test.cbuild-idx.yml")
+	writeIdx := func(csolution string, modTime time.Time) {
+		content := "build-idx:\n  csolution: " + csolution + "\n" +
+			"  cbuilds:\n    - cbuild: test.Debug+CM0.cbuild.yml\n      messages:\n        warnings:\n          - warning\n" +
+			"  executes:\n    - execute: run\n      depends-on:\n        - test.Debug+CM0\n"
+		assert.Nil(os.WriteFile(idxFile, []byte(content), 0600))
+		assert.Nil(os.Chtimes(idxFile, modTime, modTime))
+	}
+	modTime := time.Now().Add(-time.Hour)
+
+	t.Run("parse and reuse unmodified file", func(t *testing.T) {
+		writeIdx("first.csolution.yml", modTime)
+		data, err := cache.Parse(idxFile)
+		assert.Nil(err)
+		assert.Equal("first.csolution.yml", data.BuildIdx.Csolution)
+
+		data, err = cache.Parse(idxFile)
+		assert.Nil(err)
+		assert.Equal("first.csolution.yml", data.BuildIdx.Csolution)
+		assert.Equal([]string{"warning"}, data.BuildIdx.Cbuilds[0].Messages.Warnings)
+	})
+
+	t.Run("reparse file modified within the same time tick", func(t *testing.T) {
+		// same size and modification time, but different content
+		writeIdx("other.csolution.yml", modTime)
+		data, err := cache.Parse(idxFile)
+		assert.Nil(err)
+		assert.Equal("other.csolution.yml", data.BuildIdx.Csolution)
+	})
+
+	t.Run("modified copies do not change the cache", func(t *testing.T) {
+		data, err := cache.Parse(idxFile)
+		assert.Nil(err)
+		data.BuildIdx.Cbuilds[0].Cbuild = "modified"
+		data.BuildIdx.Cbuilds[0].Messages.Warnings[0] = "modified"
+		data.BuildIdx.Executes[0].(map[string]interface{})["execute"] = "modified"
+		data.BuildIdx.Cbuilds = data.BuildIdx.Cbuilds[:0]
+
+		data, err = cache.Parse(idxFile)
+		assert.Nil(err)
+		assert.Equal("test.Debug+CM0.cbuild.yml", data.BuildIdx.Cbuilds[0].Cbuild)
+		assert.Equal([]string{"warning"}, data.BuildIdx.Cbuilds[0].Messages.Warnings)
+		assert.Equal("run", data.BuildIdx.Executes[0].(map[string]interface{})["execute"])
+	})
+
+	t.Run("removed file", func(t *testing.T) {
+		assert.Nil(os.Remove(idxFile))
+		_, err := cache.Parse(idxFile)
+		assert.Error(err)
+	})
+}
@@ -7,9 +7,14 @@
 package utils
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
@@ -70,3 +75,70 @@ func AddCMakeFilesToCbuild(cmakeInfo CMakeBuildInfo) error {
 	}
 	return os.WriteFile(cmakeInfo.Cbuild, []byte(buf.String()), 0600)
 }
+
+// GetCMakeCacheFileDir returns the build directory recorded in a CMakeCache.txt file
+func GetCMakeCacheFileDir(cmakeCacheFile string) (string, error) {
+	file, err := os.Open(cmakeCacheFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	const prefixStr = "CMAKE_CACHEFILE_DIR:INTERNAL="
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, prefixStr) {
+			return strings.TrimPrefix(line, prefixStr), nil
+		}
+	}
+	return "", errutils.New(errutils.ErrCMakeCacheEntry, "CMAKE_CACHEFILE_DIR", cmakeCacheFile)
+}
+
+// RelocateBuildTree replaces the path 'oldPath' with 'newPath' in the text files
+// of the build tree 'dir', e.g. CMakeCache.txt, build.ninja, Makefiles and depfiles.
+// Binary files are left untouched. It returns the files containing 'oldPath', the
+// files are only modified when 'dryRun' is not set.
+func RelocateBuildTree(dir string, oldPath string, newPath string, dryRun bool) (updated []string, err error) {
+	var patterns []string
+	for _, path := range []string{filepath.ToSlash(oldPath), filepath.FromSlash(oldPath)} {
+		if !slices.Contains(patterns, regexp.QuoteMeta(path)) {
+			patterns = append(patterns, regexp.QuoteMeta(path))
+		}
+	}
+	// match complete path components only
+	pathRegex := regexp.MustCompile(`(?m)(` + strings.Join(patterns, "|") + `)([/\\"';\s]|$)`)
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !d.Type().IsRegular() {
+			return err
+		}
+		// #nosec G304 files of the build tree
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data, 0) >= 0 || !pathRegex.Match(data) {
+			// binary files or files without reference to the old path
+			return nil
+		}
+		updated = append(updated, path)
+		if dryRun {
+			return nil
+		}
+		data = pathRegex.ReplaceAllFunc(data, func(match []byte) []byte {
+			oldMatch := string(pathRegex.FindSubmatch(match)[1])
+			replacement := filepath.ToSlash(newPath)
+			if oldMatch != filepath.ToSlash(oldPath) {
+				replacement = filepath.FromSlash(newPath)
+			}
+			return append([]byte(replacement), match[len(oldMatch):]...)
+		})
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, info.Mode().Perm())
+	})
+	return updated, err
+}
@@ -7,9 +7,13 @@
 package utils
 
 import (
+	"encoding/json"
+	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,6 +37,177 @@ func ParseCompileCommandsFile(compileCommandsFile string) ([]CompileCommands, er
 	return data, err
 }
 
+// ContextDatabase is the compilation database of a context
+type ContextDatabase struct {
+	Context  string
+	Commands []CompileCommands
+}
+
+// compileCommandsKey returns the absolute, normalized path of the source file of an entry
+func compileCommandsKey(command CompileCommands) string {
+	file := command.File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(command.Directory, file)
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// MergeCompileCommands merges the compilation databases of several contexts into one,
+// keeping a single entry per source file. For sources shared between contexts the
+// entry of the winner context is taken, otherwise the one of the first database.
+func MergeCompileCommands(databases []ContextDatabase, winner string) []CompileCommands {
+	merged := []CompileCommands{}
+	index := make(map[string]int)
+	owner := make(map[string]string)
+	for _, database := range databases {
+		for _, command := range database.Commands {
+			key := compileCommandsKey(command)
+			i, ok := index[key]
+			if !ok {
+				index[key] = len(merged)
+				owner[key] = database.Context
+				merged = append(merged, command)
+				continue
+			}
+			if database.Context == winner && owner[key] != winner {
+				owner[key] = winner
+				merged[i] = command
+			}
+		}
+	}
+	return merged
+}
+
+// PathMap rewrites paths starting with From into paths starting with To
+type PathMap struct {
+	From string
+	To   string
+}
+
+// ParsePathMaps parses path mappings given as '<from>=<to>'
+func ParsePathMaps(pathMaps []string) ([]PathMap, error) {
+	var maps []PathMap
+	for _, pathMap := range pathMaps {
+		from, to, found := strings.Cut(pathMap, "=")
+		from = strings.TrimRight(from, "/\\")
+		to = strings.TrimRight(to, "/\\")
+		if !found || from == "" || to == "" {
+			return nil, errutils.New(errutils.ErrInvalidPathMap, pathMap)
+		}
+		maps = append(maps, PathMap{From: from, To: to})
+	}
+	return maps, nil
+}
+
+// isPathPrefixStart reports whether a path may start at the given index of a
+// command line, i.e. at the beginning of an argument, after a quote, an '=' or
+// a ',' separator, or after an option name like '-I'
+func isPathPrefixStart(command string, index int) bool {
+	if index == 0 || strings.ContainsRune(" \t\"'=,", rune(command[index-1])) {
+		return true
+	}
+	start := strings.LastIndexAny(command[:index], " \t\"'") + 1
+	option := command[start:index]
+	return len(option) > 1 && option[0] == '-' && strings.Trim(option[1:], "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ_+-") == ""
+}
+
+// isPathPrefixEnd reports whether a path prefix ends at the given index of a command line
+func isPathPrefixEnd(command string, index int) bool {
+	return index == len(command) || strings.ContainsRune("/\\ \t\"',;", rune(command[index]))
+}
+
+// ReplacePathPrefix replaces the path prefix 'from' by 'to' in all paths of a
+// string, which is either a single path or a command line
+func ReplacePathPrefix(command, from, to string) string {
+	if from == "" {
+		return command
+	}
+	var result strings.Builder
+	last := 0
+	for pos := 0; ; {
+		index := strings.Index(command[pos:], from)
+		if index < 0 {
+			break
+		}
+		index += pos
+		end := index + len(from)
+		if isPathPrefixStart(command, index) && isPathPrefixEnd(command, end) {
+			result.WriteString(command[last:index])
+			result.WriteString(to)
+			last = end
+			pos = end
+		} else {
+			pos = index + 1
+		}
+	}
+	result.WriteString(command[last:])
+	return result.String()
+}
+
+// isInsideDir reports whether the path is the directory or located inside of it
+func isInsideDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && filepath.IsAbs(path) && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// relativizeCompileCommand makes the paths of an entry located inside the base
+// directory relative. The directory becomes relative to the directory of the
+// database file as clangd resolves it, the file, the output and the paths inside
+// the command become relative to the directory as the compiler resolves them.
+func relativizeCompileCommand(entry CompileCommands, baseDir string, databaseDir string) CompileCommands {
+	directory := entry.Directory
+	if !isInsideDir(directory, baseDir) {
+		return entry
+	}
+	entry.Directory, _ = filepath.Rel(databaseDir, directory)
+	entry.Directory = filepath.ToSlash(entry.Directory)
+	for _, path := range []*string{&entry.File, &entry.Output} {
+		if isInsideDir(*path, baseDir) {
+			*path, _ = filepath.Rel(directory, *path)
+			*path = filepath.ToSlash(*path)
+		}
+	}
+	base, _ := filepath.Rel(directory, baseDir)
+	base = filepath.ToSlash(base)
+	for _, from := range []string{filepath.ToSlash(baseDir), filepath.FromSlash(baseDir)} {
+		entry.Command = ReplacePathPrefix(entry.Command, from, base)
+	}
+	return entry
+}
+
+// RemapCompileCommands rewrites the directory, file, output and the paths inside of
+// the command of all entries of a database written to the database directory. With
+// a base directory, paths inside of it are made relative first, the path mappings
+// are applied to the remaining absolute paths.
+func RemapCompileCommands(data []CompileCommands, pathMaps []PathMap, baseDir string, databaseDir string) []CompileCommands {
+	remapped := make([]CompileCommands, 0, len(data))
+	for _, entry := range data {
+		if baseDir != "" {
+			entry = relativizeCompileCommand(entry, baseDir, databaseDir)
+		}
+		for _, pathMap := range pathMaps {
+			entry.Directory = ReplacePathPrefix(entry.Directory, pathMap.From, pathMap.To)
+			entry.File = ReplacePathPrefix(entry.File, pathMap.From, pathMap.To)
+			entry.Output = ReplacePathPrefix(entry.Output, pathMap.From, pathMap.To)
+			entry.Command = ReplacePathPrefix(entry.Command, pathMap.From, pathMap.To)
+		}
+		remapped = append(remapped, entry)
+	}
+	return remapped
+}
+
+// WriteCompileCommandsFile writes a compilation database in JSON format
+func WriteCompileCommandsFile(compileCommandsFile string, data []CompileCommands) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(compileCommandsFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(compileCommandsFile, append(content, '\n'), 0600)
+}
+
 func AppendFileToGroupUniquely(fileTree *[]Filetree, group, file string) {
 	for i := range *fileTree {
 		if (*fileTree)[i].Group == group {
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeCompileCommands(t *testing.T) {
+	assert := assert.New(t)
+	src := filepath.ToSlash(t.TempDir())
+	out := filepath.ToSlash(t.TempDir())
+
+	debug := ContextDatabase{Context: "app.Debug+CM0", Commands: []CompileCommands{
+		{Directory: out + "/debug", File: src + "/main.c", Command: "gcc -O0 -c main.c"},
+		{Directory: out + "/debug", File: src + "/debug.c", Command: "gcc -O0 -c debug.c"},
+		{Directory: out + "/debug", File: src + "/main.c", Command: "gcc -O0 -DDUP -c main.c"},
+	}}
+	release := ContextDatabase{Context: "app.Release+CM0", Commands: []CompileCommands{
+		{Directory: src, File: "./main.c", Command: "gcc -O2 -c main.c"},
+		{Directory: out + "/release", File: src + "/release.c", Command: "gcc -O2 -c release.c"},
+	}}
+
+	t.Run("first database wins", func(t *testing.T) {
+		merged := MergeCompileCommands([]ContextDatabase{debug, release}, "")
+		assert.Equal([]CompileCommands{debug.Commands[0], debug.Commands[1], release.Commands[1]}, merged)
+	})
+
+	t.Run("winner context", func(t *testing.T) {
+		merged := MergeCompileCommands([]ContextDatabase{debug, release}, "app.Release+CM0")
+		assert.Equal([]CompileCommands{release.Commands[0], debug.Commands[1], release.Commands[1]}, merged)
+	})
+
+	t.Run("no databases", func(t *testing.T) {
+		assert.Empty(MergeCompileCommands(nil, ""))
+	})
+}
+
+func TestWriteCompileCommandsFile(t *testing.T) {
+	assert := assert.New(t)
+	file := filepath.Join(t.TempDir(), "sub", "compile_commands.json")
+	data := []CompileCommands{{Directory: "/out", File: "/src/main.c", Output: "main.o", Command: "gcc -c main.c"}}
+	assert.Nil(WriteCompileCommandsFile(file, data))
+	parsed, err := ParseCompileCommandsFile(file)
+	assert.Nil(err)
+	assert.Equal(data, parsed)
+}
+
+func TestParsePathMaps(t *testing.T) {
+	assert := assert.New(t)
+	pathMaps, err := ParsePathMaps([]string{"/work=/home/dev/proj", "/opt/tools/=/usr/local/tools"})
+	assert.Nil(err)
+	assert.Equal([]PathMap{{From: "/work", To: "/home/dev/proj"}, {From: "/opt/tools", To: "/usr/local/tools"}}, pathMaps)
+
+	for _, pathMap := range []string{"/work", "=/home/dev/proj", "/work="} {
+		_, err = ParsePathMaps([]string{pathMap})
+		assert.EqualError(err, "invalid path mapping '"+pathMap+"', expected <from>=<to>")
+	}
+}
+
+func TestReplacePathPrefix(t *testing.T) {
+	assert := assert.New(t)
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"/work", "/proj"},
+		{"/work/src/main.c", "/proj/src/main.c"},
+		{"/workspace/src/main.c", "/workspace/src/main.c"},
+		{"/home/work/src/main.c", "/home/work/src/main.c"},
+		{"gcc -I/work/inc -isystem /work/sys -c /work/main.c", "gcc -I/proj/inc -isystem /proj/sys -c /proj/main.c"},
+		{"gcc -DPATH=\"/work/cfg\" -Wl,/work/lib.a,/work/map -o/work", "gcc -DPATH=\"/proj/cfg\" -Wl,/proj/lib.a,/proj/map -o/proj"},
+		{"gcc -I/workspace -Ifoo/bar/work/inc", "gcc -I/workspace -Ifoo/bar/work/inc"},
+	}
+	for _, test := range testCases {
+		assert.Equal(test.expected, ReplacePathPrefix(test.input, "/work", "/proj"))
+	}
+}
+
+func TestRemapCompileCommands(t *testing.T) {
+	assert := assert.New(t)
+	solutionDir := filepath.ToSlash(t.TempDir())
+	data := []CompileCommands{{
+		Directory: solutionDir + "/out/Debug",
+		File:      solutionDir + "/src/main.c",
+		Output:    solutionDir + "/tmp/main.o",
+		Command:   "/work/bin/gcc -I" + solutionDir + "/inc -I/work/cmsis -o " + solutionDir + "/tmp/main.o -c " + solutionDir + "/src/main.c",
+	}}
+	pathMaps := []PathMap{{From: "/work", To: "/home/dev/tools"}}
+
+	t.Run("path mapping", func(t *testing.T) {
+		remapped := RemapCompileCommands(data, []PathMap{{From: solutionDir, To: "/home/dev/proj"}}, "", "")
+		assert.Equal([]CompileCommands{{
+			Directory: "/home/dev/proj/out/Debug",
+			File:      "/home/dev/proj/src/main.c",
+			Output:    "/home/dev/proj/tmp/main.o",
+			Command:   "/work/bin/gcc -I/home/dev/proj/inc -I/work/cmsis -o /home/dev/proj/tmp/main.o -c /home/dev/proj/src/main.c",
+		}}, remapped)
+	})
+
+	t.Run("relative paths", func(t *testing.T) {
+		remapped := RemapCompileCommands(data, pathMaps, solutionDir, solutionDir)
+		assert.Equal([]CompileCommands{{
+			Directory: "out/Debug",
+			File:      "../../src/main.c",
+			Output:    "../../tmp/main.o",
+			Command:   "/home/dev/tools/bin/gcc -I../../inc -I/home/dev/tools/cmsis -o ../../tmp/main.o -c ../../src/main.c",
+		}}, remapped)
+	})
+
+	t.Run("directory relative to the database file", func(t *testing.T) {
+		remapped := RemapCompileCommands(data, nil, solutionDir, solutionDir+"/out/Debug")
+		assert.Equal(".", remapped[0].Directory)
+		assert.Equal("../../src/main.c", remapped[0].File)
+
+		remapped = RemapCompileCommands(data, nil, solutionDir, solutionDir+"/db")
+		assert.Equal("../out/Debug", remapped[0].Directory)
+	})
+}
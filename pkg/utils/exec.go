@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
@@ -25,10 +26,60 @@ type RunnerInterface interface {
 	ExecuteCommand(program string, quiet bool, args ...string) (output string, err error)
 }
 
+// EnvRunnerInterface is implemented by the runners able to extend the
+// environment of the executed programs
+type EnvRunnerInterface interface {
+	WithEnv(env []string) RunnerInterface
+}
+
+// WithEnv returns a runner executing the programs with the process environment
+// extended by env. Runners without environment support are returned unchanged.
+func WithEnv(runner RunnerInterface, env []string) RunnerInterface {
+	if envRunner, ok := runner.(EnvRunnerInterface); ok && len(env) > 0 {
+		return envRunner.WithEnv(env)
+	}
+	return runner
+}
+
+// OutputRunnerInterface is implemented by the runners able to return the
+// standard error of the executed programs apart from the standard output
+type OutputRunnerInterface interface {
+	ExecuteCommandOutput(program string, args ...string) (stdout string, stderr string, err error)
+}
+
+// ExecuteCommandOutput executes a program quietly and returns its standard output
+// and standard error apart. Runners without support return an empty standard error.
+func ExecuteCommandOutput(runner RunnerInterface, program string, args ...string) (string, string, error) {
+	if outputRunner, ok := runner.(OutputRunnerInterface); ok {
+		return outputRunner.ExecuteCommandOutput(program, args...)
+	}
+	output, err := runner.ExecuteCommand(program, true, args...)
+	return output, "", err
+}
+
+// CommandEnv returns the environment of an executed program, nil keeps the
+// process environment
+func CommandEnv(env []string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	return append(os.Environ(), env...)
+}
+
 type Runner struct {
-	outBytes    []byte // Captures the output bytes from the executed command
-	quiet       bool   // If true, suppresses output to the standard logger
-	PlainOutput bool   // Indicates if a "plain output" is required instead of "interactive terminal"
+	outBytes    []byte   // Captures the output bytes from the executed command
+	quiet       bool     // If true, suppresses output to the standard logger
+	PlainOutput bool     // Indicates if a "plain output" is required instead of "interactive terminal"
+	Env         []string // Additional environment variables of the executed programs
+}
+
+func (r Runner) WithEnv(env []string) RunnerInterface {
+	r.Env = append(slices.Clone(r.Env), env...)
+	return r
+}
+
+func (r Runner) ExecuteCommandOutput(program string, args ...string) (string, string, error) {
+	return ExecuteCommandWithEnv(r.Env, program, args...)
 }
 
 func (r *Runner) Write(bytes []byte) (n int, err error) {
@@ -62,6 +113,7 @@ func (r Runner) ExecuteCommand(program string, quiet bool, args ...string) (stri
 				_ = ptmx.Resize(w, h)
 			}
 			cmd := ptmx.Command(program, args...)
+			cmd.Env = CommandEnv(r.Env)
 			go func() { _, _ = io.Copy(os.Stdout, ptmx) }()
 			err = cmd.Run()
 			if err == nil {
@@ -76,6 +128,7 @@ func (r Runner) ExecuteCommand(program string, quiet bool, args ...string) (stri
 		r.outBytes = nil
 		r.quiet = quiet
 		cmd := exec.Command(program, args...)
+		cmd.Env = CommandEnv(r.Env)
 		cmd.Stdout = &r
 		cmd.Stderr = log.StandardLogger().Out
 		err = cmd.Run()
@@ -90,6 +143,11 @@ func (r Runner) ExecuteCommand(program string, quiet bool, args ...string) (stri
 
 // This exclusive function returns the standard output and standard error as strings
 func ExecuteCommand(program string, args ...string) (string, string, error) {
+	return ExecuteCommandWithEnv(nil, program, args...)
+}
+
+// ExecuteCommandWithEnv is ExecuteCommand with the process environment extended by env
+func ExecuteCommandWithEnv(env []string, program string, args ...string) (string, string, error) {
 	// Enable tracking
 	tracker := GetTrackerInstance("")
 	if tracker != nil {
@@ -97,6 +155,7 @@ func ExecuteCommand(program string, args ...string) (string, string, error) {
 	}
 
 	cmd := exec.Command(program, args...)
+	cmd.Env = CommandEnv(env)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
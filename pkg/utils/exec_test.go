@@ -7,6 +7,9 @@
 package utils
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,4 +52,46 @@ func TestExecuteCommandEx(t *testing.T) {
 		assert.Empty(outStr)
 		assert.Equal("go invalid: unknown command\nRun 'go help' for usage.\n", errStr)
 	})
+
+	t.Run("runner returning the standard error apart", func(t *testing.T) {
+		outStr, errStr, err := ExecuteCommandOutput(Runner{}, "go", "invalid")
+		assert.Error(err)
+		assert.Empty(outStr)
+		assert.Equal("go invalid: unknown command\nRun 'go help' for usage.\n", errStr)
+	})
+
+	t.Run("runner without standard error support", func(t *testing.T) {
+		outStr, errStr, err := ExecuteCommandOutput(ProgressRunner{Out: io.Discard}, "go", "version")
+		assert.Nil(err)
+		assert.Empty(errStr)
+		assert.Regexp("(go\\sversion\\sgo([\\d.]+).*)", outStr)
+	})
+}
+
+func TestExecuteCommandWithEnv(t *testing.T) {
+	assert := assert.New(t)
+	env := []string{"GOFLAGS=-tags=envtest"}
+
+	t.Run("execute command with environment", func(t *testing.T) {
+		outStr, _, err := ExecuteCommandWithEnv(env, "go", "env", "GOFLAGS")
+		assert.Nil(err)
+		assert.Equal("-tags=envtest", strings.TrimSpace(outStr))
+	})
+
+	t.Run("runner with environment", func(t *testing.T) {
+		output, err := WithEnv(Runner{}, env).ExecuteCommand("go", true, "env", "GOFLAGS")
+		assert.Nil(err)
+		assert.Equal("-tags=envtest", strings.TrimSpace(output))
+	})
+
+	t.Run("progress runner with environment", func(t *testing.T) {
+		output, err := WithEnv(ProgressRunner{Out: io.Discard}, env).ExecuteCommand("go", true, "env", "GOFLAGS")
+		assert.Nil(err)
+		assert.Equal("-tags=envtest", strings.TrimSpace(output))
+	})
+
+	t.Run("process environment unchanged", func(t *testing.T) {
+		assert.Nil(CommandEnv(nil))
+		assert.NotEqual("-tags=envtest", os.Getenv("GOFLAGS"))
+	})
 }
@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const progressBarWidth = 20
+
+var (
+	// ninja status line, e.g. "[12/40] Building C object CMakeFiles/Hello.dir/main.c.o"
+	ninjaStatusRegex = regexp.MustCompile(`^\[(\d+)/(\d+)\]\s*(.*)$`)
+	// makefile status line, e.g. "[ 30%] Building C object CMakeFiles/Hello.dir/main.c.o"
+	makeStatusRegex = regexp.MustCompile(`^\[\s*(\d+)%\]\s*(.*)$`)
+	// terminal escape sequences emitted by the build tools
+	escapeSeqRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+)
+
+// ProgressStatus is the build state extracted from a status line of the build tool
+type ProgressStatus struct {
+	Percent int
+	File    string
+}
+
+// ParseProgressLine extracts the build state from a ninja '[n/m]' or
+// makefile '[ p%]' status line
+func ParseProgressLine(line string) (status ProgressStatus, ok bool) {
+	line = strings.TrimSpace(escapeSeqRegex.ReplaceAllString(line, ""))
+	var description string
+	if matches := ninjaStatusRegex.FindStringSubmatch(line); matches != nil {
+		finished, _ := strconv.Atoi(matches[1])
+		total, _ := strconv.Atoi(matches[2])
+		if total <= 0 {
+			return status, false
+		}
+		status.Percent = finished * 100 / total
+		description = matches[3]
+	} else if matches := makeStatusRegex.FindStringSubmatch(line); matches != nil {
+		status.Percent, _ = strconv.Atoi(matches[1])
+		description = matches[2]
+	} else {
+		return status, false
+	}
+	status.Percent = min(max(status.Percent, 0), 100)
+
+	// the processed file is the last word of the description
+	if fields := strings.Fields(description); len(fields) > 0 {
+		file := filepath.Base(filepath.FromSlash(fields[len(fields)-1]))
+		file = strings.TrimSuffix(strings.TrimSuffix(file, ".obj"), ".o")
+		status.File = file
+	}
+	return status, true
+}
+
+// FormatProgress formats the progress line of a context
+func FormatProgress(context string, status ProgressStatus, elapsed time.Duration) string {
+	filled := status.Percent * progressBarWidth / 100
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	line := fmt.Sprintf("%s [%s] %3d%% %s", context, bar, status.Percent, FormatTime(elapsed))
+	if status.File != "" {
+		line += " " + status.File
+	}
+	return line
+}
+
+// progressDisplay renders the status lines of a running build. On a terminal
+// a single line is updated in place, otherwise a plain line is printed for
+// every 10% of progress.
+type progressDisplay struct {
+	mutex       sync.Mutex
+	context     string
+	out         io.Writer
+	terminal    bool
+	width       int
+	start       time.Time
+	lastPercent int
+	rendered    bool
+}
+
+func newProgressDisplay(context string, out io.Writer) *progressDisplay {
+	display := &progressDisplay{
+		context:     context,
+		out:         out,
+		terminal:    isTerminal(),
+		start:       time.Now(),
+		lastPercent: -1,
+	}
+	if display.terminal {
+		// #nosec G115 os.Stdout.Fd() is safe here for terminal size
+		display.width, _, _ = term.GetSize(int(os.Stdout.Fd()))
+	}
+	return display
+}
+
+func (d *progressDisplay) update(status ProgressStatus) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	line := FormatProgress(d.context, status, time.Since(d.start))
+	if d.terminal {
+		if d.width > 1 && len(line) >= d.width {
+			line = line[:d.width-1]
+		}
+		fmt.Fprint(d.out, "\r\x1b[K"+line)
+		d.rendered = true
+		return
+	}
+	if d.lastPercent < 0 || status.Percent/10 > d.lastPercent/10 {
+		fmt.Fprintln(d.out, line)
+	}
+	d.lastPercent = status.Percent
+}
+
+// finish terminates the in place updated progress line
+func (d *progressDisplay) finish() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.terminal && d.rendered {
+		fmt.Fprintln(d.out)
+		d.rendered = false
+	}
+}
+
+// progressWriter feeds the status lines of the build output to the progress
+// display and keeps the remaining output for the error report
+type progressWriter struct {
+	mutex   sync.Mutex
+	pending []byte
+	display *progressDisplay
+	output  bytes.Buffer
+}
+
+func (w *progressWriter) Write(data []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.pending = append(w.pending, data...)
+	for {
+		index := bytes.IndexAny(w.pending, "\r\n")
+		if index < 0 {
+			break
+		}
+		w.processLine(string(w.pending[:index]))
+		w.pending = w.pending[index+1:]
+	}
+	return len(data), nil
+}
+
+func (w *progressWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.pending) > 0 {
+		w.processLine(string(w.pending))
+		w.pending = nil
+	}
+}
+
+func (w *progressWriter) processLine(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	if status, ok := ParseProgressLine(line); ok {
+		w.display.update(status)
+		return
+	}
+	w.output.WriteString(line + "\n")
+}
+
+// ProgressRunner executes the build tools of a context and shows a compact
+// progress line instead of the complete build output. The complete output
+// is appended to the log file, and is printed when a command fails.
+// The progress line is written to the console only, it is not passed to the
+// logger whose output also feeds the log file.
+type ProgressRunner struct {
+	Context string    // context name shown in the progress line
+	LogFile string    // optional log file receiving the complete output
+	Out     io.Writer // progress output, defaults to os.Stdout
+	Env     []string  // additional environment variables of the executed programs
+}
+
+func (r ProgressRunner) WithEnv(env []string) RunnerInterface {
+	r.Env = append(slices.Clone(r.Env), env...)
+	return r
+}
+
+func (r ProgressRunner) ExecuteCommand(program string, quiet bool, args ...string) (string, error) {
+	// Enable tracking
+	tracker := GetTrackerInstance("perf-report.json")
+	if tracker != nil {
+		tracker.StartTracking(filepath.Base(program), strings.Join(args, " "))
+	}
+
+	out := r.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	var logWriter io.Writer = io.Discard
+	if r.LogFile != "" {
+		// #nosec G304 log file path is provided by the user
+		file, err := os.OpenFile(r.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err == nil {
+			defer file.Close()
+			logWriter = file
+		}
+	}
+
+	var stdout bytes.Buffer
+	writer := &progressWriter{display: newProgressDisplay(r.Context, out)}
+	cmd := exec.Command(program, args...)
+	cmd.Env = CommandEnv(r.Env)
+	if quiet {
+		cmd.Stdout = io.MultiWriter(&stdout, logWriter)
+		cmd.Stderr = logWriter
+	} else {
+		cmd.Stdout = io.MultiWriter(&stdout, logWriter, writer)
+		cmd.Stderr = io.MultiWriter(logWriter, writer)
+	}
+	err := cmd.Run()
+	writer.Flush()
+	writer.display.finish()
+
+	if err != nil && !quiet {
+		// show the diagnostics of the failed command, the log file
+		// already received the complete output
+		_, _ = out.Write(writer.output.Bytes())
+	}
+
+	// Stop tracking
+	if tracker != nil {
+		tracker.StopTracking()
+	}
+	return stdout.String(), err
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProgressLine(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("ninja status line", func(t *testing.T) {
+		status, ok := ParseProgressLine("[3/12] Building C object CMakeFiles/Hello.dir/src/main.c.o")
+		assert.True(ok)
+		assert.Equal(ProgressStatus{Percent: 25, File: "main.c"}, status)
+	})
+
+	t.Run("ninja status line with colors", func(t *testing.T) {
+		status, ok := ParseProgressLine("\x1b[32m[12/12]\x1b[0m Linking C executable out/Hello.axf")
+		assert.True(ok)
+		assert.Equal(ProgressStatus{Percent: 100, File: "Hello.axf"}, status)
+	})
+
+	t.Run("makefile status line", func(t *testing.T) {
+		status, ok := ParseProgressLine("[ 40%] Building C object CMakeFiles/Hello.dir/retarget.c.obj")
+		assert.True(ok)
+		assert.Equal(ProgressStatus{Percent: 40, File: "retarget.c"}, status)
+	})
+
+	t.Run("no status line", func(t *testing.T) {
+		_, ok := ParseProgressLine("main.c:10:5: warning: unused variable 'x'")
+		assert.False(ok)
+		_, ok = ParseProgressLine("[0/0] nothing")
+		assert.False(ok)
+	})
+}
+
+func TestFormatProgress(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("Hello.Debug+AVH [=====>              ]  25% 00:00:05 main.c",
+		FormatProgress("Hello.Debug+AVH", ProgressStatus{Percent: 25, File: "main.c"}, 5*time.Second))
+	assert.Equal("Hello.Debug+AVH [====================] 100% 00:01:00",
+		FormatProgress("Hello.Debug+AVH", ProgressStatus{Percent: 100}, time.Minute))
+}
+
+func TestProgressDisplay(t *testing.T) {
+	assert := assert.New(t)
+	defer func(f func() bool) { isTerminal = f }(isTerminal)
+
+	t.Run("plain text output", func(t *testing.T) {
+		isTerminal = func() bool { return false }
+		var out bytes.Buffer
+		writer := &progressWriter{display: newProgressDisplay("ctx", &out)}
+		_, _ = writer.Write([]byte("[1/10] Building C object a.c.o\n[2/10] Building C object b.c.o\n"))
+		_, _ = writer.Write([]byte("warning: something\n[10/10] Linking C executable app.elf"))
+		writer.Flush()
+		writer.display.finish()
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(lines, 3)
+		assert.Contains(lines[0], " 10% ")
+		assert.Contains(lines[1], " 20% ")
+		assert.Contains(lines[2], "100%")
+		assert.Equal("warning: something\n", writer.output.String())
+	})
+
+	t.Run("terminal output", func(t *testing.T) {
+		isTerminal = func() bool { return true }
+		var out bytes.Buffer
+		writer := &progressWriter{display: newProgressDisplay("ctx", &out)}
+		_, _ = writer.Write([]byte("[1/2] Building C object a.c.o\r\n[2/2] Linking C executable app.elf\r\n"))
+		writer.display.finish()
+
+		assert.Equal(2, strings.Count(out.String(), "\r\x1b[K"))
+		assert.True(strings.HasSuffix(out.String(), "app.elf\n"))
+	})
+}
+
+func TestProgressRunner(t *testing.T) {
+	assert := assert.New(t)
+	defer func(f func() bool) { isTerminal = f }(isTerminal)
+	isTerminal = func() bool { return false }
+
+	logFile := filepath.Join(t.TempDir(), "build.log")
+	var out bytes.Buffer
+	runner := ProgressRunner{Context: "ctx", LogFile: logFile, Out: &out}
+
+	t.Run("successful command", func(t *testing.T) {
+		output, err := runner.ExecuteCommand("go", false, "version")
+		assert.Nil(err)
+		assert.Regexp("(go\\sversion\\sgo([\\d.]+).*)", output)
+		// the output is kept in the log file only
+		assert.Empty(out.String())
+		content, _ := os.ReadFile(logFile)
+		assert.Contains(string(content), "go version")
+	})
+
+	t.Run("failed command", func(t *testing.T) {
+		out.Reset()
+		_, err := runner.ExecuteCommand("go", false, "invalid")
+		assert.Error(err)
+		assert.Contains(out.String(), "go invalid: unknown command")
+	})
+
+	t.Run("quiet command", func(t *testing.T) {
+		out.Reset()
+		_, err := runner.ExecuteCommand("go", true, "invalid")
+		assert.Error(err)
+		assert.Empty(out.String())
+	})
+}
+
+// TestProgressHelperProcess is no real test, it emulates a failing build tool
+// executed by TestProgressRunnerWithLogFile
+func TestProgressHelperProcess(t *testing.T) {
+	if os.Getenv("CBUILD_PROGRESS_HELPER") != "1" {
+		return
+	}
+	fmt.Println("[1/2] Building C object CMakeFiles/Hello.dir/main.c.o")
+	fmt.Println("main.c:10:5: error: unknown type name 'foo'")
+	fmt.Println("[2/2] Linking C executable Hello.axf")
+	fmt.Println("ninja: build stopped: subcommand failed.")
+	os.Exit(1)
+}
+
+func TestProgressRunnerWithLogFile(t *testing.T) {
+	assert := assert.New(t)
+	defer func(f func() bool) { isTerminal = f }(isTerminal)
+	isTerminal = func() bool { return true }
+
+	// emulate '--progress --log', the logger writes to the console and to the log file
+	logFile := filepath.Join(t.TempDir(), "build.log")
+	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	assert.Nil(err)
+	defer file.Close()
+	console, err := os.Create(filepath.Join(t.TempDir(), "console.txt"))
+	assert.Nil(err)
+	defer console.Close()
+
+	defer func(stdout *os.File, out io.Writer) {
+		os.Stdout = stdout
+		log.SetOutput(out)
+	}(os.Stdout, log.StandardLogger().Out)
+	os.Stdout = console
+	log.SetOutput(io.MultiWriter(console, file))
+
+	runner := ProgressRunner{Context: "ctx", LogFile: logFile,
+		Env: []string{"CBUILD_PROGRESS_HELPER=1"}}
+	_, err = runner.ExecuteCommand(os.Args[0], false, "-test.run=^TestProgressHelperProcess$")
+	assert.Error(err)
+
+	content, _ := os.ReadFile(logFile)
+	assert.NotContains(string(content), "\x1b")
+	assert.Equal(1, strings.Count(string(content), "error: unknown type name 'foo'"))
+	assert.Equal(1, strings.Count(string(content), "ninja: build stopped"))
+	assert.Equal(1, strings.Count(string(content), "[1/2] Building C object"))
+
+	// the console shows the progress line and the diagnostics of the failed command
+	output, _ := os.ReadFile(console.Name())
+	assert.Contains(string(output), "\r\x1b[K")
+	assert.Contains(string(output), "error: unknown type name 'foo'")
+}
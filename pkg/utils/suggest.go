@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+)
+
+// maxSuggestions is the maximum number of suggestions added to an error
+const maxSuggestions = 3
+
+// EditDistance returns the case insensitive Levenshtein distance of two strings
+func EditDistance(a string, b string) int {
+	s := []rune(strings.ToLower(a))
+	t := []rune(strings.ToLower(b))
+
+	previous := make([]int, len(t)+1)
+	current := make([]int, len(t)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(t)]
+}
+
+// GetSuggestions returns the candidates with the smallest edit distance to the input.
+// Candidates differing in more than a third of the input are ignored.
+func GetSuggestions(input string, candidates []string) []string {
+	type suggestion struct {
+		candidate string
+		distance  int
+	}
+	threshold := max(1, len(input)/3)
+
+	var suggestions []suggestion
+	for _, candidate := range RemoveDuplicates(candidates) {
+		if candidate == "" || candidate == input {
+			continue
+		}
+		if distance := EditDistance(input, candidate); distance <= threshold {
+			suggestions = append(suggestions, suggestion{candidate, distance})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	// only the closest candidates are suggested
+	var result []string
+	for _, s := range suggestions {
+		if len(result) == maxSuggestions || s.distance > suggestions[0].distance {
+			break
+		}
+		result = append(result, s.candidate)
+	}
+	return result
+}
+
+// AddSuggestions extends the error message by the candidates closest to the input
+func AddSuggestions(err error, input string, candidates []string) error {
+	suggestions := GetSuggestions(input, candidates)
+	if len(suggestions) == 0 {
+		return err
+	}
+	for i, suggestion := range suggestions {
+		suggestions[i] = "'" + suggestion + "'"
+	}
+	text := suggestions[len(suggestions)-1]
+	if len(suggestions) > 1 {
+		text = strings.Join(suggestions[:len(suggestions)-1], ", ") + " or " + text
+	}
+	return errutils.New(errutils.ErrDidYouMean, err.Error(), text)
+}
+
+// getContextSuggestionCandidates returns the available contexts reduced to the
+// items specified in the filter, e.g. the project names for a filter without
+// build-type and target-type
+func getContextSuggestionCandidates(allContext []string, filter string) (candidates []string) {
+	filterContextItem, err := ParseContext(filter)
+	if err != nil {
+		return nil
+	}
+	for _, context := range allContext {
+		contextItem, err := ParseContext(context)
+		if err != nil {
+			continue
+		}
+		var candidate ContextItem
+		if filterContextItem.ProjectName != "" {
+			candidate.ProjectName = contextItem.ProjectName
+		}
+		if filterContextItem.BuildType != "" {
+			candidate.BuildType = contextItem.BuildType
+		}
+		if filterContextItem.TargetType != "" {
+			candidate.TargetType = contextItem.TargetType
+		}
+		candidates = append(candidates, CreateContext(candidate))
+	}
+	return candidates
+}
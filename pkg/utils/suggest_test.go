@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, EditDistance("", ""))
+	assert.Equal(3, EditDistance("", "GCC"))
+	assert.Equal(0, EditDistance("gcc", "GCC"))
+	assert.Equal(1, EditDistance("GCCC", "GCC"))
+	assert.Equal(2, EditDistance("tset", "test"))
+	assert.Equal(3, EditDistance("kitten", "sitting"))
+}
+
+func TestGetSuggestions(t *testing.T) {
+	assert := assert.New(t)
+	candidates := []string{"AC5", "AC6", "GCC", "IAR", "CLANG"}
+
+	assert.Equal([]string{"GCC"}, GetSuggestions("GCCC", candidates))
+	assert.Equal([]string{"AC6", "AC5"}, GetSuggestions("AC7", []string{"AC6", "AC5", "AC6"}))
+	assert.Equal([]string{"CLANG"}, GetSuggestions("clamg", candidates))
+	assert.Empty(GetSuggestions("Keil", candidates))
+	assert.Empty(GetSuggestions("GCC", []string{"GCC"}))
+}
+
+func TestAddSuggestions(t *testing.T) {
+	assert := assert.New(t)
+	err := errors.New("unknown toolchain 'AC7'")
+
+	assert.EqualError(AddSuggestions(err, "AC7", []string{"GCC"}), "unknown toolchain 'AC7'")
+	assert.EqualError(AddSuggestions(err, "AC7", []string{"AC6"}), "unknown toolchain 'AC7'. Did you mean 'AC6'?")
+	assert.EqualError(AddSuggestions(err, "AC7", []string{"AC6", "AC5", "AC8"}), "unknown toolchain 'AC7'. Did you mean 'AC6', 'AC5' or 'AC8'?")
+}
+
+func TestContextSuggestions(t *testing.T) {
+	assert := assert.New(t)
+	allContexts := []string{"Hello.Debug+CM3", "Hello.Release+CM3", "World.Debug+CM0"}
+
+	_, err := ResolveContexts(allContexts, []string{"Helo"})
+	assert.EqualError(err, "no valid context found for 'Helo'. Did you mean 'Hello'?")
+
+	_, err = ResolveContexts(allContexts, []string{".Relase"})
+	assert.EqualError(err, "no valid context found for '.Relase'. Did you mean '.Release'?")
+
+	_, err = ResolveContexts(allContexts, []string{"Hello.Debug+CM4"})
+	assert.EqualError(err, "no valid context found for 'Hello.Debug+CM4'. Did you mean 'Hello.Debug+CM3'?")
+
+	_, err = ResolveContexts(allContexts, []string{"Hel*+CM4"})
+	assert.EqualError(err, "no valid context found for 'Hel*+CM4'")
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Open-CMSIS-Pack/cbuild/v2/pkg/errutils"
+	log "github.com/Open-CMSIS-Pack/cbuild/v2/pkg/logger"
+)
+
+const ToolchainsFile = "toolchains.yml"
+
+// ToolchainEntry registers the installation path of a toolchain version
+type ToolchainEntry struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Path    string `yaml:"path"`
+}
+
+type ToolchainsConfig struct {
+	Toolchains []ToolchainEntry `yaml:"toolchains"`
+}
+
+var (
+	toolchainNameRegex    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+	toolchainVersionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+)
+
+// EnvVar returns the name of the environment variable registering the toolchain
+func (t ToolchainEntry) EnvVar() string {
+	return strings.ToUpper(t.Name) + "_TOOLCHAIN_" + strings.ReplaceAll(t.Version, ".", "_")
+}
+
+// GetUserToolchainsFile returns the path of the user-level toolchains.yml
+func GetUserToolchainsFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "cbuild", ToolchainsFile)
+}
+
+// ReadToolchainsFile reads and validates the toolchain entries of a toolchains.yml,
+// relative paths are resolved against the directory of the file
+func ReadToolchainsFile(file string) ([]ToolchainEntry, error) {
+	var config ToolchainsConfig
+	if err := ParseYAMLFile(file, &config); err != nil {
+		return nil, err
+	}
+	for i, toolchain := range config.Toolchains {
+		if !toolchainNameRegex.MatchString(toolchain.Name) {
+			return nil, errutils.New(errutils.ErrInvalidToolchainEntry, file, "name '"+toolchain.Name+"'")
+		}
+		if !toolchainVersionRegex.MatchString(toolchain.Version) {
+			return nil, errutils.New(errutils.ErrInvalidToolchainEntry, file, "version '"+toolchain.Version+"' of "+toolchain.Name+", expected <major>.<minor>.<patch>")
+		}
+		if toolchain.Path == "" {
+			return nil, errutils.New(errutils.ErrInvalidToolchainEntry, file, "missing path of "+toolchain.Name+"@"+toolchain.Version)
+		}
+		if !filepath.IsAbs(toolchain.Path) {
+			config.Toolchains[i].Path = filepath.Join(filepath.Dir(file), toolchain.Path)
+		}
+	}
+	return config.Toolchains, nil
+}
+
+// LoadToolchains reads the user-level and the solution-level toolchains.yml,
+// entries of the solution override entries of the user with the same name and version
+func LoadToolchains(solutionDir string) (toolchains []ToolchainEntry, err error) {
+	var files []string
+	if userFile := GetUserToolchainsFile(); userFile != "" {
+		files = append(files, userFile)
+	}
+	if solutionDir != "" {
+		files = append(files, filepath.Join(solutionDir, ToolchainsFile))
+	}
+
+	index := make(map[string]int)
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		entries, err := ReadToolchainsFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if i, ok := index[entry.EnvVar()]; ok {
+				toolchains[i] = entry
+				continue
+			}
+			index[entry.EnvVar()] = len(toolchains)
+			toolchains = append(toolchains, entry)
+		}
+	}
+	return toolchains, nil
+}
+
+// GetToolchainsEnv returns the environment variables registering the toolchains
+// listed in the toolchains.yml files, to be passed to the executed programs.
+// Variables already set in the environment take precedence.
+func GetToolchainsEnv(solutionDir string) (env []string, err error) {
+	toolchains, err := LoadToolchains(solutionDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, toolchain := range toolchains {
+		envVar := toolchain.EnvVar()
+		if os.Getenv(envVar) != "" {
+			log.Debug(envVar + " is already set, ignoring " + ToolchainsFile + " entry")
+			continue
+		}
+		log.Debug(envVar + "=" + toolchain.Path)
+		env = append(env, envVar+"="+toolchain.Path)
+	}
+	return env, nil
+}
+
+// GetEnv returns the value of an environment variable, variables of env take
+// precedence over the process environment
+func GetEnv(env []string, name string) string {
+	for i := len(env) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(env[i], name+"="); ok {
+			return value
+		}
+	}
+	return os.Getenv(name)
+}
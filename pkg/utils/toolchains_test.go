@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026 Arm Limited. All rights reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setUserConfigDir(t *testing.T, dir string) {
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
+
+func TestToolchainEntry(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("GCC_TOOLCHAIN_13_2_1", ToolchainEntry{Name: "GCC", Version: "13.2.1"}.EnvVar())
+	assert.Equal("CLANG_TOOLCHAIN_19_1_5", ToolchainEntry{Name: "Clang", Version: "19.1.5"}.EnvVar())
+}
+
+func TestReadToolchainsFile(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, ToolchainsFile)
+
+	t.Run("valid entries", func(t *testing.T) {
+		content := "toolchains:\n" +
+			"  - name: GCC\n    version: 13.2.1\n    path: /opt/gcc/bin\n" +
+			"  - name: AC6\n    version: 6.22.0\n    path: tools/ac6/bin\n"
+		assert.Nil(os.WriteFile(file, []byte(content), 0600))
+		toolchains, err := ReadToolchainsFile(file)
+		assert.Nil(err)
+		assert.Equal([]ToolchainEntry{
+			{Name: "GCC", Version: "13.2.1", Path: "/opt/gcc/bin"},
+			{Name: "AC6", Version: "6.22.0", Path: filepath.Join(dir, "tools/ac6/bin")},
+		}, toolchains)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		content := "toolchains:\n  - name: GCC\n    version: 13.2\n    path: /opt/gcc/bin\n"
+		assert.Nil(os.WriteFile(file, []byte(content), 0600))
+		_, err := ReadToolchainsFile(file)
+		assert.EqualError(err, "invalid toolchain entry in '"+file+"': version '13.2' of GCC, expected <major>.<minor>.<patch>")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		content := "toolchains:\n  - name: GCC-ARM\n    version: 13.2.1\n    path: /opt/gcc/bin\n"
+		assert.Nil(os.WriteFile(file, []byte(content), 0600))
+		_, err := ReadToolchainsFile(file)
+		assert.EqualError(err, "invalid toolchain entry in '"+file+"': name 'GCC-ARM'")
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		content := "toolchains:\n  - name: GCC\n    version: 13.2.1\n"
+		assert.Nil(os.WriteFile(file, []byte(content), 0600))
+		_, err := ReadToolchainsFile(file)
+		assert.EqualError(err, "invalid toolchain entry in '"+file+"': missing path of GCC@13.2.1")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadToolchainsFile(filepath.Join(dir, "unknown.yml"))
+		assert.Error(err)
+	})
+}
+
+func TestRegisterToolchains(t *testing.T) {
+	assert := assert.New(t)
+	userDir := t.TempDir()
+	solutionDir := t.TempDir()
+	setUserConfigDir(t, userDir)
+
+	userFile := GetUserToolchainsFile()
+	assert.Nil(os.MkdirAll(filepath.Dir(userFile), 0755))
+	assert.Nil(os.WriteFile(userFile, []byte("toolchains:\n"+
+		"  - name: GCC\n    version: 13.2.1\n    path: /user/gcc/bin\n"+
+		"  - name: AC6\n    version: 6.22.0\n    path: /user/ac6/bin\n"+
+		"  - name: IAR\n    version: 9.50.1\n    path: /user/iar/bin\n"), 0600))
+	assert.Nil(os.WriteFile(filepath.Join(solutionDir, ToolchainsFile), []byte("toolchains:\n"+
+		"  - name: AC6\n    version: 6.22.0\n    path: /solution/ac6/bin\n"+
+		"  - name: CLANG\n    version: 19.1.5\n    path: /solution/clang/bin\n"), 0600))
+
+	for _, envVar := range []string{"GCC_TOOLCHAIN_13_2_1", "AC6_TOOLCHAIN_6_22_0", "CLANG_TOOLCHAIN_19_1_5"} {
+		t.Setenv(envVar, "")
+	}
+	// registrations in the environment take precedence
+	t.Setenv("IAR_TOOLCHAIN_9_50_1", "/env/iar/bin")
+
+	t.Run("load user and solution toolchains", func(t *testing.T) {
+		toolchains, err := LoadToolchains(solutionDir)
+		assert.Nil(err)
+		assert.Equal([]ToolchainEntry{
+			{Name: "GCC", Version: "13.2.1", Path: "/user/gcc/bin"},
+			{Name: "AC6", Version: "6.22.0", Path: "/solution/ac6/bin"},
+			{Name: "IAR", Version: "9.50.1", Path: "/user/iar/bin"},
+			{Name: "CLANG", Version: "19.1.5", Path: "/solution/clang/bin"},
+		}, toolchains)
+	})
+
+	t.Run("toolchains environment", func(t *testing.T) {
+		env, err := GetToolchainsEnv(solutionDir)
+		assert.Nil(err)
+		assert.Equal([]string{
+			"GCC_TOOLCHAIN_13_2_1=/user/gcc/bin",
+			"AC6_TOOLCHAIN_6_22_0=/solution/ac6/bin",
+			"CLANG_TOOLCHAIN_19_1_5=/solution/clang/bin",
+		}, env)
+		assert.Equal("/solution/ac6/bin", GetEnv(env, "AC6_TOOLCHAIN_6_22_0"))
+		assert.Equal("/env/iar/bin", GetEnv(env, "IAR_TOOLCHAIN_9_50_1"))
+		// the process environment is left unchanged
+		assert.Empty(os.Getenv("GCC_TOOLCHAIN_13_2_1"))
+	})
+
+	t.Run("toolchains changed in between", func(t *testing.T) {
+		assert.Nil(os.WriteFile(filepath.Join(solutionDir, ToolchainsFile), []byte("toolchains:\n"+
+			"  - name: AC6\n    version: 6.22.0\n    path: /solution/ac6-update/bin\n"), 0600))
+		env, err := GetToolchainsEnv(solutionDir)
+		assert.Nil(err)
+		assert.Equal("/solution/ac6-update/bin", GetEnv(env, "AC6_TOOLCHAIN_6_22_0"))
+	})
+
+	t.Run("without solution", func(t *testing.T) {
+		toolchains, err := LoadToolchains("")
+		assert.Nil(err)
+		assert.Equal(3, len(toolchains))
+	})
+
+	t.Run("invalid solution toolchains", func(t *testing.T) {
+		assert.Nil(os.WriteFile(filepath.Join(solutionDir, ToolchainsFile), []byte("toolchains:\n  - name: GCC\n"), 0600))
+		_, err := GetToolchainsEnv(solutionDir)
+		assert.Error(err)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -98,57 +99,36 @@ func GetDefaultCmsisPackRoot() (root string) {
 	return filepath.Clean(root)
 }
 
+// ParseContext splits a context name [<project-name>][.<build-type>][+<target-type>]
+// into its items. Project names may contain dots, the build-type follows the last dot
+// before the target-type.
 func ParseContext(context string) (item ContextItem, err error) {
 	parseError := errutils.New(errutils.ErrInvalidContextFormat)
 
-	periodCount := strings.Count(context, ".")
 	plusCount := strings.Count(context, "+")
-	if context == "" || periodCount > 1 || plusCount > 1 {
+	if context == "" || plusCount > 1 {
 		err = parseError
 		return
 	}
 
-	var projectName, buildType, targetType string
-
-	targetIdx := strings.Index(context, "+")
-	buildIdx := strings.Index(context, ".")
-
-	if (targetIdx != -1 && buildIdx != -1) && targetIdx < buildIdx {
+	projectPart, targetType, _ := strings.Cut(context, "+")
+	if strings.Contains(targetType, ".") {
+		// build-type is expected before the target-type
 		err = parseError
 		return
 	}
 
-	if targetIdx == -1 && buildIdx == -1 {
-		projectName = context
-	} else if buildIdx == -1 {
-		// context with only projectName+targetType
-		projectName = context[:targetIdx]
-		targetType = context[targetIdx+1:]
-	} else if targetIdx == -1 {
-		// context with only projectName.buildtype
-		projectName = context[:buildIdx]
-		buildType = context[buildIdx+1:]
-	} else {
-		// fully specified contexts
-		part := context[:targetIdx]
-		buildIdx := strings.Index(part, ".")
-
-		if buildIdx > -1 {
-			projectName = part[:buildIdx]
-			buildType = part[buildIdx+1:]
-		} else {
-			projectName = part
-		}
-
-		part = context[targetIdx+1:]
-		buildIdx = strings.Index(part, ".")
+	projectName := projectPart
+	var buildType string
+	if buildIdx := strings.LastIndex(projectPart, "."); buildIdx != -1 {
+		projectName = projectPart[:buildIdx]
+		buildType = projectPart[buildIdx+1:]
+	}
 
-		if buildIdx > -1 {
-			targetType = part[:buildIdx]
-			buildType = part[buildIdx+1:]
-		} else {
-			targetType = part
-		}
+	// dots are only allowed between the parts of a project name
+	if projectName != "" && slices.Contains(strings.Split(projectName, "."), "") {
+		err = parseError
+		return
 	}
 
 	item.ProjectName = projectName
@@ -173,6 +153,7 @@ type CbuildIndex struct {
 		GeneratedBy string `yaml:"generated-by"`
 		Cdefault    string `yaml:"cdefault"`
 		Csolution   string `yaml:"csolution"`
+		CbuildRun   string `yaml:"cbuild-run"`
 		TmpDir      string `yaml:"tmpdir"`
 		Cprojects   []struct {
 			Cproject string `yaml:"cproject"`
@@ -228,13 +209,59 @@ type Csolution struct {
 	} `yaml:"solution"`
 }
 
+type OutputFile struct {
+	Type string `yaml:"type"`
+	File string `yaml:"file"`
+}
+
+type CbuildComponent struct {
+	Component  string `yaml:"component"`
+	Condition  string `yaml:"condition"`
+	FromPack   string `yaml:"from-pack"`
+	SelectedBy string `yaml:"selected-by"`
+}
+
+// SplitComponentID splits a component identifier '<vendor>::<class>:<group>@<version>'
+// into the identifier without version and the version
+func SplitComponentID(id string) (name string, version string) {
+	if idx := strings.LastIndex(id, "@"); idx >= 0 {
+		return id[:idx], id[idx+1:]
+	}
+	return id, ""
+}
+
+type CbuildFile struct {
+	File     string `yaml:"file"`
+	Category string `yaml:"category"`
+}
+
+type CbuildGroup struct {
+	Group  string        `yaml:"group"`
+	Files  []CbuildFile  `yaml:"files"`
+	Groups []CbuildGroup `yaml:"groups"`
+}
+
+// GetGroupFiles returns the files of the groups and of their nested groups
+func GetGroupFiles(groups []CbuildGroup) (files []string) {
+	for _, group := range groups {
+		for _, file := range group.Files {
+			files = append(files, file.File)
+		}
+		files = append(files, GetGroupFiles(group.Groups)...)
+	}
+	return files
+}
+
 type Cbuild struct {
 	Build struct {
 		OutputDirs struct {
 			Intdir string `yaml:"intdir"`
 			Outdir string `yaml:"outdir"`
 		} `yaml:"output-dirs"`
-		West struct {
+		Output     []OutputFile      `yaml:"output"`
+		Components []CbuildComponent `yaml:"components"`
+		Groups     []CbuildGroup     `yaml:"groups"`
+		West       struct {
 			AppPath string `yaml:"app-path"`
 		} `yaml:"west"`
 		CMake struct {
@@ -244,6 +271,39 @@ type Cbuild struct {
 	} `yaml:"build"`
 }
 
+type CbuildRunOutput struct {
+	File       string `yaml:"file"`
+	Info       string `yaml:"info"`
+	Type       string `yaml:"type"`
+	Load       string `yaml:"load"`
+	LoadOffset string `yaml:"load-offset"`
+	Pname      string `yaml:"pname"`
+}
+
+type CbuildRunGdbServer struct {
+	Port  int    `yaml:"port"`
+	Pname string `yaml:"pname"`
+}
+
+type CbuildRun struct {
+	Run struct {
+		GeneratedBy string            `yaml:"generated-by"`
+		Solution    string            `yaml:"solution"`
+		TargetType  string            `yaml:"target-type"`
+		TargetSet   string            `yaml:"target-set"`
+		Compiler    string            `yaml:"compiler"`
+		Board       string            `yaml:"board"`
+		Device      string            `yaml:"device"`
+		Output      []CbuildRunOutput `yaml:"output"`
+		Debugger    struct {
+			Name      string               `yaml:"name"`
+			Protocol  string               `yaml:"protocol"`
+			Clock     int                  `yaml:"clock"`
+			Gdbserver []CbuildRunGdbServer `yaml:"gdbserver"`
+		} `yaml:"debugger"`
+	} `yaml:"cbuild-run"`
+}
+
 func ParseYAMLFile(filePath string, out interface{}) error {
 	// Read the file
 	yfile, err := os.ReadFile(filePath)
@@ -281,6 +341,12 @@ func ParseCbuildFile(cbuildFile string) (Cbuild, error) {
 	return data, err
 }
 
+func ParseCbuildRunFile(cbuildRunFile string) (CbuildRun, error) {
+	var data CbuildRun
+	err := ParseYAMLFile(cbuildRunFile, &data)
+	return data, err
+}
+
 func AppendUnique[T comparable](slice []T, elems ...T) []T {
 	lookup := make(map[T]struct{})
 	all := append(slice, elems...)
@@ -319,48 +385,127 @@ func NormalizePath(path string) string {
 	return path
 }
 
-func ResolveContexts(allContext []string, contextFilters []string) ([]string, error) {
-	var selectedContexts []string
+const (
+	// ContextExcludePrefix marks context filters excluding the matching contexts
+	ContextExcludePrefix = "!"
+	// ContextRegexPrefix marks context filters given as regular expression
+	ContextRegexPrefix = "re:"
+)
 
-	// remove duplicates (if any)
-	filters := RemoveDuplicates(contextFilters)
+// ContextSelection holds the options of SelectContexts
+type ContextSelection struct {
+	Exclude    []string // filters of contexts to be excluded
+	AllowEmpty bool     // do not fail on filters matching no context
+}
 
-	for _, filter := range filters {
-		filterContextItem, err := ParseContext(filter)
+// HasContextExpressions checks if any filter uses an exclusion or a regular expression
+func HasContextExpressions(contextFilters []string) bool {
+	for _, filter := range contextFilters {
+		if strings.HasPrefix(filter, ContextExcludePrefix) || strings.HasPrefix(filter, ContextRegexPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// getContextPatterns returns the wildcard patterns matching the full context names
+// [<project-name>].[<build-type>]+[<target-type>] selected by the filter
+func getContextPatterns(filter string) ([]string, error) {
+	filterContextItem, err := ParseContext(filter)
+	if err != nil {
+		return nil, err
+	}
+	createPattern := func(item ContextItem) string {
+		pattern := "*"
+		if item.ProjectName != "" {
+			pattern = item.ProjectName
+		}
+		pattern += "."
+		if item.BuildType != "" {
+			pattern += item.BuildType
+		} else {
+			pattern += "*"
+		}
+		pattern += "+"
+		if item.TargetType != "" {
+			pattern += item.TargetType
+		} else {
+			pattern += "*"
+		}
+		return pattern
+	}
+
+	patterns := []string{createPattern(filterContextItem)}
+	if filterContextItem.ProjectName != "" && filterContextItem.BuildType != "" {
+		// 'a.b' is also the name of a project containing a dot
+		patterns = append(patterns, createPattern(ContextItem{
+			ProjectName: filterContextItem.ProjectName + "." + filterContextItem.BuildType,
+			TargetType:  filterContextItem.TargetType,
+		}))
+	}
+	return patterns, nil
+}
+
+// matchContext checks if the context is selected by the filter. Filters starting
+// with 're:' are regular expressions matching the complete context name.
+func matchContext(context string, filter string) (bool, error) {
+	if expression, ok := strings.CutPrefix(filter, ContextRegexPrefix); ok {
+		re, err := regexp.Compile("^(?:" + expression + ")$")
 		if err != nil {
-			return nil, err
+			return false, errutils.New(errutils.ErrInvalidContextRegex, expression, err)
 		}
-		matchFound := false
-		for _, context := range allContext {
-			availableContextItem, err := ParseContext(context)
-			if err != nil {
-				return nil, err
-			}
+		return re.MatchString(context), nil
+	}
 
-			var contextPattern string
-			if filterContextItem.ProjectName != "" {
-				contextPattern = filterContextItem.ProjectName
-			} else {
-				contextPattern = "*"
-			}
+	contextPatterns, err := getContextPatterns(filter)
+	if err != nil {
+		return false, err
+	}
+	availableContextItem, err := ParseContext(context)
+	if err != nil {
+		return false, err
+	}
+	fullContextItem := availableContextItem.ProjectName + "." + availableContextItem.BuildType + "+" + availableContextItem.TargetType
+	for _, contextPattern := range contextPatterns {
+		match, err := MatchString(fullContextItem, contextPattern)
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
 
-			contextPattern += "."
-			if filterContextItem.BuildType != "" {
-				contextPattern += filterContextItem.BuildType
-			} else {
-				contextPattern += "*"
-			}
+func ResolveContexts(allContext []string, contextFilters []string) ([]string, error) {
+	return SelectContexts(allContext, contextFilters, ContextSelection{})
+}
 
-			contextPattern += "+"
-			if filterContextItem.TargetType != "" {
-				contextPattern += filterContextItem.TargetType
-			} else {
-				contextPattern += "*"
-			}
+// SelectContexts returns the contexts matching the filters. Filters starting with '!'
+// and the filters of 'selection.Exclude' remove the matching contexts from the selection,
+// all contexts are selected if only exclusions are given.
+func SelectContexts(allContext []string, contextFilters []string, selection ContextSelection) ([]string, error) {
+	var selectedContexts, includeFilters, excludeFilters []string
+	if len(contextFilters) == 0 && len(selection.Exclude) == 0 {
+		return nil, nil
+	}
 
-			fullContextItem := availableContextItem.ProjectName + "." + availableContextItem.BuildType + "+" + availableContextItem.TargetType
+	// remove duplicates (if any)
+	filters := RemoveDuplicates(contextFilters)
+	for _, filter := range filters {
+		if excludeFilter, ok := strings.CutPrefix(filter, ContextExcludePrefix); ok {
+			excludeFilters = append(excludeFilters, excludeFilter)
+		} else {
+			includeFilters = append(includeFilters, filter)
+		}
+	}
+	excludeFilters = append(excludeFilters, selection.Exclude...)
 
-			match, err := MatchString(fullContextItem, contextPattern)
+	if len(includeFilters) == 0 {
+		selectedContexts = append(selectedContexts, allContext...)
+	}
+	for _, filter := range includeFilters {
+		matchFound := false
+		for _, context := range allContext {
+			match, err := matchContext(context, filter)
 			if err != nil {
 				return nil, err
 			}
@@ -372,12 +517,88 @@ func ResolveContexts(allContext []string, contextFilters []string) ([]string, er
 			}
 		}
 		if !matchFound {
-			return nil, errutils.New(errutils.ErrNoFilteredContextFound, filter)
+			err := errutils.New(errutils.ErrNoFilteredContextFound, filter)
+			if !IsWildcardPattern(filter) && !strings.HasPrefix(filter, ContextRegexPrefix) {
+				err = AddSuggestions(err, filter, getContextSuggestionCandidates(allContext, filter))
+			}
+			if !selection.AllowEmpty {
+				return nil, err
+			}
+			log.Warn(err.Error())
+		}
+	}
+
+	for _, filter := range excludeFilters {
+		var remainingContexts []string
+		for _, context := range selectedContexts {
+			match, err := matchContext(context, filter)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				remainingContexts = append(remainingContexts, context)
+			}
 		}
+		selectedContexts = remainingContexts
+	}
+
+	if len(selectedContexts) == 0 && !selection.AllowEmpty {
+		return nil, errutils.New(errutils.ErrNoFilteredContextFound, strings.Join(append(filters, selection.Exclude...), " "))
 	}
 	return selectedContexts, nil
 }
 
+// SplitCommandLine splits a command line into its arguments like a POSIX shell,
+// arguments containing spaces are enclosed in single or double quotes. A backslash
+// only escapes quotes, backslashes and spaces to keep Windows paths intact.
+func SplitCommandLine(cmdLine string) (args []string, err error) {
+	isEscaped := func(next rune, inDoubleQuotes bool) bool {
+		if inDoubleQuotes {
+			return next == '"' || next == '\\'
+		}
+		return strings.ContainsRune("\"'\\ \t", next)
+	}
+
+	var arg strings.Builder
+	var quote rune
+	inArg := false
+	runes := []rune(cmdLine)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && quote != '\'' && i+1 < len(runes) && isEscaped(runes[i+1], quote == '"'):
+			i++
+			arg.WriteRune(runes[i])
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errutils.New(errutils.ErrUnclosedQuote, cmdLine)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
 func LogStdMsg(msg string) {
 	if msg != "" {
 		_, _ = log.StandardLogger().Out.Write([]byte(msg + "\n"))
@@ -457,12 +678,20 @@ func isFileSystemCaseInsensitive() bool {
 	return filepath.Separator == '\\' || strings.Contains(strings.ToLower(os.Getenv("OS")), "darwin")
 }
 
+// GetTmpDir returns the tmp directory of the solution, located in the '--output'
+// folder if given, otherwise next to the csolution file. Like the cbuild-idx.yml
+// generated by csolution, a relative '--output' folder is resolved against the
+// working directory and not against the directory of the csolution file.
 func GetTmpDir(csolutionFile string, outputDir string) (string, error) {
 	// Default temporary directory name
 	const defaultTmpDir = "tmp"
 
-	// Get the base directory of the csolution file
+	// Get the base directory of the output files, either the '--output'
+	// folder or the directory of the csolution file
 	basePath := filepath.Dir(csolutionFile)
+	if outputDir != "" {
+		basePath = outputDir
+	}
 
 	// Parse the csolution file
 	data, err := ParseCsolutionFile(csolutionFile)
@@ -473,7 +702,7 @@ func GetTmpDir(csolutionFile string, outputDir string) (string, error) {
 		}
 
 		// For other parsing errors, fallback to the default tmp directory
-		tmpPath := filepath.Join(basePath, outputDir, defaultTmpDir)
+		tmpPath := filepath.Join(basePath, defaultTmpDir)
 		return NormalizePath(tmpPath), nil
 	}
 
@@ -483,10 +712,31 @@ func GetTmpDir(csolutionFile string, outputDir string) (string, error) {
 		tmpDir = defaultTmpDir
 	}
 
-	tmpPath := filepath.Join(basePath, outputDir, tmpDir)
+	if filepath.IsAbs(tmpDir) {
+		return NormalizePath(tmpDir), nil
+	}
+	tmpPath := filepath.Join(basePath, tmpDir)
 	return NormalizePath(tmpPath), nil
 }
 
+// GetContextDirName returns the name of the context specific directory in the tmp
+// directory, which is also the name of the CMake targets of the context
+func GetContextDirName(context string) string {
+	return strings.ReplaceAll(context, " ", "_")
+}
+
+// CheckCsolutionFile checks the extension and the existence of the *.csolution.yml
+// argument of a command
+func CheckCsolutionFile(inputFile string) error {
+	fileName := filepath.Base(inputFile)
+	expectedExtension := ".csolution.yml"
+	if !strings.HasSuffix(fileName, expectedExtension) && !strings.HasSuffix(fileName, ".csolution.yaml") {
+		return errutils.New(errutils.ErrInvalidFileExtension, fileName, expectedExtension)
+	}
+	_, err := FileExists(inputFile)
+	return err
+}
+
 func GetOutDir(cbuildIdxFile string, context string) (string, error) {
 	basePath := filepath.Dir(cbuildIdxFile)
 	defaultOutPath := filepath.Join(basePath, "out")
@@ -648,11 +898,114 @@ func DeleteAll(path string, excludeFilePatterns []string) error {
 	return nil
 }
 
-func ParseAndFetchToolchainInfo(toolchainFile string) string {
+// PathEntry is a file or directory affected by DeleteAll
+type PathEntry struct {
+	Path  string
+	Size  int64
+	IsDir bool
+}
+
+// ListDeleteAll reports which files and directories DeleteAll would remove
+// and which files are preserved by the exclude patterns, without modifying
+// the file system. Directories are reported as removed when no preserved
+// file is left in them.
+func ListDeleteAll(path string, excludeFilePatterns []string) (removed []PathEntry, preserved []PathEntry, err error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil, nil // nothing to delete
+	}
+
+	keptDirs := make(map[string]bool)
+	var dirs []PathEntry
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, PathEntry{Path: p, IsDir: true})
+			return nil
+		}
+		var size int64
+		if info, err := d.Info(); err == nil {
+			size = info.Size()
+		}
+		entry := PathEntry{Path: p, Size: size}
+		shouldExclude, err := matchAnyPattern(d.Name(), excludeFilePatterns)
+		if err != nil {
+			return err
+		}
+		if shouldExclude {
+			preserved = append(preserved, entry)
+			// keep all parent directories of the preserved file
+			for dir := filepath.Dir(p); ; dir = filepath.Dir(dir) {
+				keptDirs[dir] = true
+				if dir == path || dir == filepath.Dir(dir) {
+					break
+				}
+			}
+			return nil
+		}
+		removed = append(removed, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, dir := range dirs {
+		// the root directory is only deleted when no patterns are given
+		if keptDirs[dir.Path] || (dir.Path == path && len(excludeFilePatterns) > 0) {
+			continue
+		}
+		removed = append(removed, dir)
+	}
+	return removed, preserved, nil
+}
+
+// GetDirSize returns the total size of the files in the directory tree
+func GetDirSize(path string) (size int64) {
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// FormatSize formats a number of bytes in binary units, e.g. "1.5 MiB"
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// ToolchainInfo describes the toolchain selected for a context
+type ToolchainInfo struct {
+	Name    string `yaml:"name" json:"name"`
+	Version string `yaml:"version" json:"version"`
+	Root    string `yaml:"root" json:"root"`
+}
+
+func (t ToolchainInfo) String() string {
+	return fmt.Sprintf("%s V%s from '%s'", t.Name, t.Version, t.Root)
+}
+
+// GetToolchainInfo reads the registered toolchain name, version and root from
+// a context specific toolchain.cmake file
+func GetToolchainInfo(toolchainFile string) (info ToolchainInfo, ok bool) {
 	// Open the toolchain.cmake file
 	file, err := os.Open(toolchainFile)
 	if err != nil {
-		return ""
+		return info, false
 	}
 	defer file.Close()
 
@@ -661,7 +1014,6 @@ func ParseAndFetchToolchainInfo(toolchainFile string) string {
 	versionPattern := `set\(REGISTERED_TOOLCHAIN_VERSION\s+"([^"]+)"\)`
 	compilerPattern := `include\("\${CMSIS_COMPILER_ROOT}/(.*)\.\d+\.\d+\.\d+\.cmake"\)`
 
-	var toolchainRoot, toolchainVersion, compilerName string
 	// Scan toolchain.cmake file
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
@@ -669,26 +1021,53 @@ func ParseAndFetchToolchainInfo(toolchainFile string) string {
 
 		// Get toolchain root
 		if matches := regexp.MustCompile(rootPattern).FindStringSubmatch(line); len(matches) > 1 {
-			toolchainRoot = matches[1]
+			info.Root = matches[1]
 		}
 
 		// Get matched toolchain version
 		if matches := regexp.MustCompile(versionPattern).FindStringSubmatch(line); len(matches) > 1 {
-			toolchainVersion = matches[1]
+			info.Version = matches[1]
 		}
 
 		// Get matched toolchain name
 		if matches := regexp.MustCompile(compilerPattern).FindStringSubmatch(line); len(matches) > 1 {
-			compilerName = strings.Split(matches[1], ".")[0]
+			info.Name = strings.Split(matches[1], ".")[0]
 		}
 	}
 
 	// Check all required values were found
-	if toolchainRoot == "" || toolchainVersion == "" || compilerName == "" {
+	if info.Root == "" || info.Version == "" || info.Name == "" {
+		return ToolchainInfo{}, false
+	}
+	return info, true
+}
+
+func ParseAndFetchToolchainInfo(toolchainFile string) string {
+	info, ok := GetToolchainInfo(toolchainFile)
+	if !ok {
 		return ""
 	}
+	return fmt.Sprintf("Using %s V%s compiler, from: '%s'", info.Name, info.Version, info.Root)
+}
 
-	return fmt.Sprintf("Using %s V%s compiler, from: '%s'", compilerName, toolchainVersion, toolchainRoot)
+// ReadToolchainInfo reads the toolchain info recorded by WriteToolchainInfo
+func ReadToolchainInfo(file string) (info ToolchainInfo, err error) {
+	// #nosec G304 file path is generated by cbuild
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return info, err
+	}
+	err = yaml.Unmarshal(data, &info)
+	return info, err
+}
+
+// WriteToolchainInfo records the toolchain info of a build
+func WriteToolchainInfo(file string, info ToolchainInfo) error {
+	data, err := yaml.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0600)
 }
 
 func GetParentFolder(path string) (string, error) {
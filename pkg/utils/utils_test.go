@@ -174,6 +174,12 @@ func TestParseContext(t *testing.T) {
 		{"Project.+Target", false, ContextItem{ProjectName: "Project", BuildType: "", TargetType: "Target"}},
 		{"Project+Target", false, ContextItem{ProjectName: "Project", BuildType: "", TargetType: "Target"}},
 		{"Project.Build+Target", false, ContextItem{ProjectName: "Project", BuildType: "Build", TargetType: "Target"}},
+
+		// project names containing dots
+		{"my.Project.Build+Target", false, ContextItem{ProjectName: "my.Project", BuildType: "Build", TargetType: "Target"}},
+		{"my.Project.+Target", false, ContextItem{ProjectName: "my.Project", BuildType: "", TargetType: "Target"}},
+		{"my..Project.Build+Target", true, ContextItem{}},
+		{"my.Project.Build+Target.Build", true, ContextItem{}},
 	}
 	for _, test := range testCases {
 		contextItem, err := ParseContext(test.Input)
@@ -239,6 +245,33 @@ func TestParseCbuildIndexFile(t *testing.T) {
 	})
 }
 
+func TestParseCbuildComponents(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := ParseCbuildFile(filepath.Join(testRoot, testDir, "Hello.Debug+AVH.cbuild.yml"))
+	assert.Nil(err)
+	assert.Equal("elf", data.Build.Output[0].Type)
+	assert.Equal("Hello.axf", data.Build.Output[0].File)
+	assert.Greater(len(data.Build.Components), 2)
+	component := data.Build.Components[1]
+	assert.Equal("ARM::CMSIS:CORE@5.6.0", component.Component)
+	assert.Equal("ARMv6_7_8-M Device", component.Condition)
+	assert.Equal("ARM::CMSIS@5.9.0", component.FromPack)
+	assert.Equal("CMSIS:CORE", component.SelectedBy)
+}
+
+func TestSplitComponentID(t *testing.T) {
+	assert := assert.New(t)
+
+	name, version := SplitComponentID("ARM::CMSIS:RTOS2:Keil RTX5&Source@5.5.4")
+	assert.Equal("ARM::CMSIS:RTOS2:Keil RTX5&Source", name)
+	assert.Equal("5.5.4", version)
+
+	name, version = SplitComponentID("ARM::Device:Startup")
+	assert.Equal("ARM::Device:Startup", name)
+	assert.Empty(version)
+}
+
 func TestParseCbuildSetFile(t *testing.T) {
 	assert := assert.New(t)
 
@@ -393,6 +426,88 @@ func TestResolveContexts(t *testing.T) {
 	}
 }
 
+func TestSelectContexts(t *testing.T) {
+	assert := assert.New(t)
+
+	allContexts := []string{
+		"Project1.Debug+Target",
+		"Project1.Release+Target",
+		"Project1.Debug+Simulation",
+		"my.Project.Debug+Target",
+		"my.Project.Release+Simulation",
+	}
+
+	testCases := []struct {
+		contextFilters           []string
+		selection                ContextSelection
+		expectedSelectedContexts []string
+		ExpectError              bool
+	}{
+		{[]string{"!*+Simulation"}, ContextSelection{}, []string{"Project1.Debug+Target", "Project1.Release+Target", "my.Project.Debug+Target"}, false},
+		{[]string{"Project1", "!.Release"}, ContextSelection{}, []string{"Project1.Debug+Target", "Project1.Debug+Simulation"}, false},
+		{[]string{"Project1"}, ContextSelection{Exclude: []string{"+Simulation", ".Release"}}, []string{"Project1.Debug+Target"}, false},
+		{nil, ContextSelection{Exclude: []string{"Project1"}}, []string{"my.Project.Debug+Target", "my.Project.Release+Simulation"}, false},
+		{[]string{"re:Project1\\.(Debug|Release)\\+Target"}, ContextSelection{}, []string{"Project1.Debug+Target", "Project1.Release+Target"}, false},
+		{[]string{"re:.*Debug.*", "!re:my\\..*"}, ContextSelection{}, []string{"Project1.Debug+Target", "Project1.Debug+Simulation"}, false},
+		{[]string{"my.Project"}, ContextSelection{}, []string{"my.Project.Debug+Target", "my.Project.Release+Simulation"}, false},
+		{[]string{"my.Project.Release"}, ContextSelection{}, []string{"my.Project.Release+Simulation"}, false},
+		{[]string{"my.*+Target"}, ContextSelection{}, []string{"my.Project.Debug+Target"}, false},
+		{[]string{"Unknown", "Project1.Debug+Target"}, ContextSelection{AllowEmpty: true}, []string{"Project1.Debug+Target"}, false},
+		{[]string{"Unknown"}, ContextSelection{AllowEmpty: true}, nil, false},
+		{[]string{"!*"}, ContextSelection{AllowEmpty: true}, nil, false},
+
+		// negative tests
+		{[]string{"Unknown", "Project1.Debug+Target"}, ContextSelection{}, nil, true},
+		{[]string{"!*"}, ContextSelection{}, nil, true},
+		{[]string{"Project1"}, ContextSelection{Exclude: []string{"Project1"}}, nil, true},
+		{[]string{"re:Project1("}, ContextSelection{}, nil, true},
+		{[]string{"re:Project1"}, ContextSelection{}, nil, true},
+	}
+
+	for idx, test := range testCases {
+		outSelectedContexts, err := SelectContexts(allContexts, test.contextFilters, test.selection)
+		caseInfo := func() string {
+			return fmt.Sprintf("TestCase #%d: contextFilters=%v selection=%v", idx, test.contextFilters, test.selection)
+		}
+		if test.ExpectError {
+			assert.Error(err, caseInfo())
+		} else {
+			assert.Nil(err, caseInfo())
+		}
+		assert.Equal(test.expectedSelectedContexts, outSelectedContexts, caseInfo())
+	}
+
+	t.Run("context expressions", func(t *testing.T) {
+		assert.False(HasContextExpressions([]string{"Project1", "*+Target"}))
+		assert.True(HasContextExpressions([]string{"Project1", "!*+Target"}))
+		assert.True(HasContextExpressions([]string{"re:Project.*"}))
+	})
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	assert := assert.New(t)
+	testCases := []struct {
+		cmdLine string
+		args    []string
+	}{
+		{"", nil},
+		{"  qemu-system-arm   -M  mps2-an385 ", []string{"qemu-system-arm", "-M", "mps2-an385"}},
+		{`"/opt/my tools/fvp" --app={exe}`, []string{"/opt/my tools/fvp", "--app={exe}"}},
+		{`run -C 'a="b c"' ""`, []string{"run", "-C", `a="b c"`, ""}},
+		{`run "say \"hi\"" my\ file`, []string{"run", `say "hi"`, "my file"}},
+		{`C:\tools\fvp.exe -a {exe}`, []string{`C:\tools\fvp.exe`, "-a", "{exe}"}},
+		{`run 'C:\my dir\'`, []string{"run", `C:\my dir\`}},
+	}
+	for _, test := range testCases {
+		args, err := SplitCommandLine(test.cmdLine)
+		assert.Nil(err, test.cmdLine)
+		assert.Equal(test.args, args, test.cmdLine)
+	}
+
+	_, err := SplitCommandLine(`run 'unclosed`)
+	assert.EqualError(err, "unclosed quote in command line 'run 'unclosed'")
+}
+
 func TestRemoveDuplicates(t *testing.T) {
 	assert := assert.New(t)
 
@@ -500,6 +615,20 @@ func TestComparePaths(t *testing.T) {
 	})
 }
 
+func TestCheckCsolutionFile(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(CheckCsolutionFile(filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")))
+	assert.EqualError(CheckCsolutionFile("test.cproject.yml"), "invalid file extension: 'test.cproject.yml'. Expected: '.csolution.yml'")
+	unknownFile := filepath.Join(testRoot, testDir, "TestSolution/unknown.csolution.yaml")
+	assert.EqualError(CheckCsolutionFile(unknownFile), "file "+unknownFile+" does not exist")
+}
+
+func TestGetContextDirName(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("test.Debug+CM0", GetContextDirName("test.Debug+CM0"))
+	assert.Equal("my_test.Debug+My_Board", GetContextDirName("my test.Debug+My Board"))
+}
+
 func TestGetTmpDir(t *testing.T) {
 	t.Run("File exists with specified tmpdir", func(t *testing.T) {
 		csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
@@ -509,6 +638,32 @@ func TestGetTmpDir(t *testing.T) {
 		assert.Equal(t, NormalizePath(filepath.Join(filepath.Dir(csolutionFile), "tmpdir")), tmpDir)
 	})
 
+	t.Run("File exists with output directory", func(t *testing.T) {
+		csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml")
+		outputDir := filepath.Join(testRoot, testDir, "output")
+		tmpDir, err := GetTmpDir(csolutionFile, outputDir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, NormalizePath(filepath.Join(outputDir, "tmpdir")), tmpDir)
+
+		absOutputDir, _ := filepath.Abs(outputDir)
+		tmpDir, err = GetTmpDir(csolutionFile, absOutputDir)
+		assert.NoError(t, err)
+		assert.Equal(t, NormalizePath(filepath.Join(absOutputDir, "tmpdir")), tmpDir)
+	})
+
+	t.Run("Relative output directory", func(t *testing.T) {
+		// a relative output directory is resolved against the working directory
+		csolutionFile, _ := filepath.Abs(filepath.Join(testRoot, testDir, "TestSolution/test.csolution.yml"))
+		t.Chdir(t.TempDir())
+		tmpDir, err := GetTmpDir(csolutionFile, "build")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "build/tmpdir", tmpDir)
+		absTmpDir, _ := filepath.Abs(tmpDir)
+		assert.False(t, strings.HasPrefix(absTmpDir, filepath.Dir(csolutionFile)))
+	})
+
 	t.Run("File does not exist", func(t *testing.T) {
 		csolutionFile := filepath.Join(testRoot, testDir, "TestSolution/non_existing.csolution.yml")
 		tmpDir, err := GetTmpDir(csolutionFile, "")
@@ -900,3 +1055,143 @@ func TestGetCompilerRootFromRootsCMake(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestListDeleteAll(t *testing.T) {
+	assert := assert.New(t)
+	delDir := filepath.Join(testRoot, testDir, "test_dir_list_delete")
+	_ = os.MkdirAll(filepath.Join(delDir, "logs"), 0755)
+	_ = os.MkdirAll(filepath.Join(delDir, "obj"), 0755)
+	_ = os.WriteFile(filepath.Join(delDir, "logs", "keep.log"), []byte("keep"), 0600)
+	_ = os.WriteFile(filepath.Join(delDir, "logs", "delete.txt"), []byte("delete"), 0600)
+	_ = os.WriteFile(filepath.Join(delDir, "obj", "main.o"), []byte("object"), 0600)
+	defer os.RemoveAll(delDir)
+
+	t.Run("with exclude patterns", func(t *testing.T) {
+		removed, preserved, err := ListDeleteAll(delDir, []string{"*.log"})
+		assert.Nil(err)
+		assert.Equal([]PathEntry{{Path: filepath.Join(delDir, "logs", "keep.log"), Size: 4}}, preserved)
+		assert.ElementsMatch([]PathEntry{
+			{Path: filepath.Join(delDir, "logs", "delete.txt"), Size: 6},
+			{Path: filepath.Join(delDir, "obj", "main.o"), Size: 6},
+			{Path: filepath.Join(delDir, "obj"), IsDir: true},
+		}, removed)
+		// nothing has been deleted
+		assert.FileExists(filepath.Join(delDir, "obj", "main.o"))
+	})
+
+	t.Run("without exclude patterns", func(t *testing.T) {
+		removed, preserved, err := ListDeleteAll(delDir, []string{})
+		assert.Nil(err)
+		assert.Empty(preserved)
+		assert.Len(removed, 6)
+		assert.Contains(removed, PathEntry{Path: delDir, IsDir: true})
+	})
+
+	t.Run("non-existent path", func(t *testing.T) {
+		removed, preserved, err := ListDeleteAll(filepath.Join(delDir, "unknown"), []string{})
+		assert.Nil(err)
+		assert.Empty(removed)
+		assert.Empty(preserved)
+	})
+}
+
+func TestFormatSize(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("0 B", FormatSize(0))
+	assert.Equal("1023 B", FormatSize(1023))
+	assert.Equal("1.0 KiB", FormatSize(1024))
+	assert.Equal("1.5 MiB", FormatSize(3*512*1024))
+	assert.Equal("2.0 GiB", FormatSize(2*1024*1024*1024))
+}
+
+func TestToolchainInfo(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	toolchainFile := filepath.Join(dir, "toolchain.cmake")
+	content := "set(REGISTERED_TOOLCHAIN_ROOT \"/opt/gcc/bin\")\n" +
+		"set(REGISTERED_TOOLCHAIN_VERSION \"13.2.1\")\n" +
+		"include(\"${CMSIS_COMPILER_ROOT}/GCC.10.3.1.cmake\")\n"
+	_ = os.WriteFile(toolchainFile, []byte(content), 0600)
+
+	t.Run("get toolchain info", func(t *testing.T) {
+		info, ok := GetToolchainInfo(toolchainFile)
+		assert.True(ok)
+		assert.Equal(ToolchainInfo{Name: "GCC", Version: "13.2.1", Root: "/opt/gcc/bin"}, info)
+		assert.Equal("GCC V13.2.1 from '/opt/gcc/bin'", info.String())
+	})
+
+	t.Run("get toolchain info missing file", func(t *testing.T) {
+		_, ok := GetToolchainInfo(filepath.Join(dir, "unknown.cmake"))
+		assert.False(ok)
+	})
+
+	t.Run("write and read toolchain info", func(t *testing.T) {
+		recordFile := filepath.Join(dir, "cbuild-toolchain.yml")
+		info := ToolchainInfo{Name: "AC6", Version: "6.22.0", Root: "/opt/ac6/bin"}
+		assert.Nil(WriteToolchainInfo(recordFile, info))
+		recorded, err := ReadToolchainInfo(recordFile)
+		assert.Nil(err)
+		assert.Equal(info, recorded)
+	})
+
+	t.Run("read toolchain info missing file", func(t *testing.T) {
+		_, err := ReadToolchainInfo(filepath.Join(dir, "unknown.yml"))
+		assert.Error(err)
+	})
+}
+
+func TestRelocateBuildTree(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	textFile := filepath.Join(dir, "build.ninja")
+	binaryFile := filepath.Join(dir, ".ninja_deps")
+	otherFile := filepath.Join(dir, "sub", "rules.cmake")
+	_ = os.MkdirAll(filepath.Dir(otherFile), 0755)
+	_ = os.WriteFile(textFile, []byte("build /old/proj/tmp/main.o: CC /old/proj/main.c\ninclude /old/project/rules.ninja\nroot /old/proj\n"), 0600)
+	_ = os.WriteFile(binaryFile, []byte("/old/proj/main.c\x00"), 0600)
+	_ = os.WriteFile(otherFile, []byte("set(ROOT \"/opt/packs\")\n"), 0600)
+
+	t.Run("dry run", func(t *testing.T) {
+		updated, err := RelocateBuildTree(dir, "/old/proj", "/new/location/proj", true)
+		assert.Nil(err)
+		assert.Equal([]string{textFile}, updated)
+		content, _ := os.ReadFile(textFile)
+		assert.Contains(string(content), "/old/proj/main.c")
+	})
+
+	t.Run("relocate", func(t *testing.T) {
+		updated, err := RelocateBuildTree(dir, "/old/proj", "/new/location/proj", false)
+		assert.Nil(err)
+		assert.Equal([]string{textFile}, updated)
+		content, _ := os.ReadFile(textFile)
+		assert.Equal("build /new/location/proj/tmp/main.o: CC /new/location/proj/main.c\ninclude /old/project/rules.ninja\nroot /new/location/proj\n", string(content))
+		content, _ = os.ReadFile(binaryFile)
+		assert.Equal("/old/proj/main.c\x00", string(content))
+	})
+}
+
+func TestGetCMakeCacheFileDir(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	cmakeCacheFile := filepath.Join(dir, "CMakeCache.txt")
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := GetCMakeCacheFileDir(cmakeCacheFile)
+		assert.Error(err)
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		_ = os.WriteFile(cmakeCacheFile, []byte("CMAKE_HOME_DIRECTORY:INTERNAL=/proj/tmp\n"), 0600)
+		_, err := GetCMakeCacheFileDir(cmakeCacheFile)
+		assert.EqualError(err, "CMAKE_CACHEFILE_DIR not found in '"+cmakeCacheFile+"'")
+	})
+
+	t.Run("entry found", func(t *testing.T) {
+		_ = os.WriteFile(cmakeCacheFile, []byte("CMAKE_CACHEFILE_DIR:INTERNAL=/proj/tmp\n"), 0600)
+		path, err := GetCMakeCacheFileDir(cmakeCacheFile)
+		assert.Nil(err)
+		assert.Equal("/proj/tmp", path)
+	})
+}